@@ -47,6 +47,13 @@ func main() {
 
 	var userLocks sync.Map
 
+	auditor, err := app.InitAuditor(st.Conn, sugar)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
 	withdrawalApp, err := app.InitWithdrawal(st.Conn, cfg, sugar)
 	if err != nil {
 		sugar.Fatalw("Unable to init application",
@@ -54,14 +61,70 @@ func main() {
 		)
 	}
 
-	userApp, err := app.InitUserApp(st.Conn, *withdrawalApp, cfg, sugar, &userLocks)
+	userApp, err := app.InitUserApp(st.Conn, *withdrawalApp, cfg, sugar, &userLocks, auditor)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	orderApp, err := app.InitOrderApp(st.Conn, cfg, sugar, utils.InitAccrual(cfg.AccrualSystemAddress+"/api/orders"), auditor, &userLocks)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	sessionApp, err := app.InitSessionApp(st.Conn, cfg, sugar, auditor)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	adminApp, err := app.InitAdminApp(st.Conn, cfg, sugar, &userLocks, auditor)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	partnerApp, err := app.InitPartnerApp(st.Conn, orderApp, cfg, sugar)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	merchantApp, err := app.InitMerchantApp(st.Conn, orderApp, cfg, sugar, &userLocks, auditor)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	disputeApp, err := app.InitDisputeApp(st.Conn, cfg, sugar, &userLocks, auditor)
 	if err != nil {
 		sugar.Fatalw("Unable to init application",
 			"Error", err,
 		)
 	}
 
-	orderApp, err := app.InitOrderApp(st.Conn, cfg, sugar, utils.InitAccrual(cfg.AccrualSystemAddress+"/api/orders"))
+	tierApp, err := app.InitTierApp(st.Conn, cfg, sugar, auditor)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	promoApp, err := app.InitPromoApp(st.Conn, cfg, sugar, &userLocks, auditor)
+	if err != nil {
+		sugar.Fatalw("Unable to init application",
+			"Error", err,
+		)
+	}
+
+	householdApp, err := app.InitHouseholdApp(st.Conn, cfg, sugar, &userLocks, auditor)
 	if err != nil {
 		sugar.Fatalw("Unable to init application",
 			"Error", err,
@@ -71,8 +134,18 @@ func main() {
 	userHn := handler.InitUserHandler(userApp, cfg, sugar)
 	orderHn := handler.InitOrderHandler(orderApp, cfg, sugar)
 	withdrawalHn := handler.InitWithdrawalHandler(withdrawalApp, cfg, sugar)
-	authMw := middleware.InitAuth(cfg)
-	r := router.InitRouter(cfg, authMw, userHn, orderHn, withdrawalHn)
+	sessionHn := handler.InitSessionHandler(sessionApp, cfg, sugar)
+	adminHn := handler.InitAdminHandler(adminApp, cfg, sugar)
+	partnerHn := handler.InitPartnerHandler(partnerApp, cfg, sugar)
+	merchantHn := handler.InitMerchantHandler(merchantApp, cfg, sugar)
+	disputeHn := handler.InitDisputeHandler(disputeApp, cfg, sugar)
+	tierHn := handler.InitTierHandler(tierApp, cfg, sugar)
+	promoHn := handler.InitPromoHandler(promoApp, cfg, sugar)
+	householdHn := handler.InitHouseholdHandler(householdApp, cfg, sugar)
+	authMw := middleware.InitAuth(cfg, sessionApp.Session)
+	apiKeyMw := middleware.InitAPIKeyAuth(cfg, adminApp.Keys)
+	merchantMw := middleware.InitMerchantAuth(merchantApp.Merchant)
+	r := router.InitRouter(cfg, authMw, apiKeyMw, merchantMw, userHn, orderHn, withdrawalHn, sessionHn, adminHn, partnerHn, merchantHn, disputeHn, tierHn, promoHn, householdHn)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	defer stop()
@@ -94,6 +167,20 @@ func main() {
 		gr.Done()
 	}()
 
+	gr.Add(1)
+
+	go func() {
+		service.InitExpirer(ctx, *cfg, sugar, userApp)
+		gr.Done()
+	}()
+
+	gr.Add(1)
+
+	go func() {
+		service.InitTierRecalculator(ctx, *cfg, sugar, tierApp)
+		gr.Done()
+	}()
+
 	gr.Add(1)
 	go func() {
 		err = server.ListenAndServe()
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+func (app *UserApp) ExportData(ctx context.Context, uid string) (sharedTypes.UserData, error) {
+	return app.User.ExportUserData(ctx, uid)
+}
+
+func (app *UserApp) DeleteAccount(ctx context.Context, uid string, req sharedTypes.AccountDeletion) error {
+	user, err := app.User.GetUserByID(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	ok, _, err := app.Hasher.Verify(ctx, req.Password, user.PasswordHash)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return utils.ErrForbidden
+	}
+
+	if user.TOTPEnabled {
+		ok, err = app.verifySecondFactor(ctx, user, req.Code)
+
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return utils.ErrInvalidCode
+		}
+	}
+
+	anonUID, err := app.User.DeleteUser(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	app.Auditor.Record(ctx, AuditUserDelete, userTarget(uid), nil, map[string]string{"anonymised_uid": anonUID})
+
+	return nil
+}
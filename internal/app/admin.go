@@ -0,0 +1,397 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	AdjustmentReasonGoodwill     = "goodwill"
+	AdjustmentReasonCorrection   = "correction"
+	AdjustmentReasonCompensation = "compensation"
+	AdjustmentReasonOther        = "other"
+	// AdjustmentReasonExpiry is only written by the expiry job.
+	AdjustmentReasonExpiry = "expiry"
+)
+
+const (
+	maxAdjustmentComment = 500
+	maxAPIKeyName        = 100
+	maxMerchantName      = 100
+	maxCardID            = 64
+)
+
+type AdminApp struct {
+	Admin      sharedTypes.AdminStorager
+	Adjustment sharedTypes.AdjustmentStorager
+	Keys       sharedTypes.APIKeyStorager
+	Merchants  sharedTypes.MerchantStorager
+	Campaigns  sharedTypes.CampaignStorager
+	Cfg        *config.Config
+	logger     *zap.SugaredLogger
+	UserLocks  *sync.Map
+	Auditor    *Auditor
+}
+
+func InitAdminApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, ul *sync.Map, auditor *Auditor) (*AdminApp, error) {
+	admin, err := storage.InitAdmin(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment, err := storage.InitAdjustment(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := storage.InitAPIKey(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	merchants, err := storage.InitMerchant(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns, err := storage.InitCampaign(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminApp{admin, adjustment, keys, merchants, campaigns, cfg, logger, ul, auditor}, nil
+}
+
+func (app *AdminApp) SearchUsers(ctx context.Context, login string) ([]sharedTypes.UserSummary, error) {
+	return app.Admin.SearchUsers(ctx, login, app.Cfg.AdminSearchLimit)
+}
+
+func (app *AdminApp) GetUser(ctx context.Context, uid string) (sharedTypes.UserDetail, error) {
+	return app.Admin.GetUserDetail(ctx, uid, app.Cfg.AdminRecentOrders)
+}
+
+func (app *AdminApp) GetOrder(ctx context.Context, number string) (sharedTypes.OrderOwner, error) {
+	return app.Admin.GetOrder(ctx, number)
+}
+
+func (app *AdminApp) CreateAdjustment(ctx context.Context, adminUID string, adj sharedTypes.Adjustment) (sharedTypes.Adjustment, error) {
+	adj.Comment = strings.TrimSpace(adj.Comment)
+
+	if adj.Amount == 0 || adj.Comment == "" || len(adj.Comment) > maxAdjustmentComment {
+		return sharedTypes.Adjustment{}, utils.ErrWrongFormat
+	}
+
+	switch adj.Reason {
+	case AdjustmentReasonGoodwill, AdjustmentReasonCorrection, AdjustmentReasonCompensation, AdjustmentReasonOther:
+	default:
+		return sharedTypes.Adjustment{}, utils.ErrWrongFormat
+	}
+
+	adj.AdminUID = adminUID
+
+	rawLock, _ := app.UserLocks.LoadOrStore(adj.UID, &sync.Mutex{})
+	lock, ok := rawLock.(*sync.Mutex)
+
+	if !ok {
+		return sharedTypes.Adjustment{}, errors.New("wrong lock type")
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	created, err := app.Adjustment.CreateAdjustment(ctx, adj)
+
+	if err != nil {
+		return sharedTypes.Adjustment{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditAdminAdjustment, userTarget(adj.UID), nil, created)
+
+	return created, nil
+}
+
+func (app *AdminApp) SetSuspended(ctx context.Context, uid string, suspended bool) error {
+	found, err := app.Admin.SetSuspended(ctx, uid, suspended)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	action := AuditAdminUnsuspend
+	if suspended {
+		action = AuditAdminSuspend
+	}
+
+	app.Auditor.Record(ctx, action, userTarget(uid), nil, nil)
+
+	return nil
+}
+
+func (app *AdminApp) SetFrozen(ctx context.Context, uid string, frozen bool) error {
+	found, err := app.Admin.SetFrozen(ctx, uid, frozen)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	action := AuditAdminUnfreeze
+	if frozen {
+		action = AuditAdminFreeze
+	}
+
+	app.Auditor.Record(ctx, action, userTarget(uid), nil, nil)
+
+	return nil
+}
+
+func (app *AdminApp) MergeUsers(ctx context.Context, adminUID string, req sharedTypes.MergeRequest) (sharedTypes.UserMerge, error) {
+	source, err := strconv.ParseInt(req.SourceUID, 10, 32)
+
+	if err != nil {
+		return sharedTypes.UserMerge{}, utils.ErrWrongFormat
+	}
+
+	target, err := strconv.ParseInt(req.TargetUID, 10, 32)
+
+	if err != nil || source == target {
+		return sharedTypes.UserMerge{}, utils.ErrWrongFormat
+	}
+
+	merge := sharedTypes.UserMerge{
+		SourceUID: strconv.FormatInt(source, 10),
+		TargetUID: strconv.FormatInt(target, 10),
+		AdminUID:  adminUID,
+	}
+
+	unlock, err := lockUsers(app.UserLocks, merge.SourceUID, merge.TargetUID)
+
+	if err != nil {
+		return sharedTypes.UserMerge{}, err
+	}
+
+	defer unlock()
+
+	merge, err = app.Admin.MergeUsers(ctx, merge)
+
+	if err != nil {
+		return sharedTypes.UserMerge{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditAdminMerge, userTarget(merge.TargetUID), nil, merge)
+
+	return merge, nil
+}
+
+func (app *AdminApp) CreateAPIKey(ctx context.Context, adminUID string, req sharedTypes.APIKeyRequest) (sharedTypes.APIKeyCreated, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	if req.Name == "" || len(req.Name) > maxAPIKeyName || len(req.Scopes) == 0 || req.RateLimit < 0 {
+		return sharedTypes.APIKeyCreated{}, utils.ErrWrongFormat
+	}
+
+	scopes := []string{}
+
+	for _, scope := range req.Scopes {
+		switch scope {
+		case sharedTypes.ScopeOrdersWrite, sharedTypes.ScopeBalanceRead:
+		default:
+			return sharedTypes.APIKeyCreated{}, utils.ErrWrongFormat
+		}
+
+		if !utils.HasScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if req.RateLimit == 0 {
+		req.RateLimit = app.Cfg.PartnerRateLimit
+	}
+
+	key, prefix, err := utils.GenerateAPIKey()
+
+	if err != nil {
+		return sharedTypes.APIKeyCreated{}, err
+	}
+
+	created, err := app.Keys.CreateAPIKey(ctx, sharedTypes.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		Hash:      utils.HashAPIKey(key),
+		CreatedBy: adminUID,
+		Scopes:    scopes,
+		RateLimit: req.RateLimit,
+	})
+
+	if err != nil {
+		return sharedTypes.APIKeyCreated{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditAdminAPIKeyCreate, apiKeyTarget(created.ID), nil, created)
+
+	return sharedTypes.APIKeyCreated{APIKey: created, Key: key}, nil
+}
+
+func (app *AdminApp) ListAPIKeys(ctx context.Context) ([]sharedTypes.APIKey, error) {
+	return app.Keys.ListAPIKeys(ctx)
+}
+
+func (app *AdminApp) RevokeAPIKey(ctx context.Context, id string) error {
+	found, err := app.Keys.RevokeAPIKey(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditAdminAPIKeyRevoke, apiKeyTarget(id), nil, nil)
+
+	return nil
+}
+
+func (app *AdminApp) CreateMerchant(ctx context.Context, adminUID string, req sharedTypes.MerchantRequest) (sharedTypes.MerchantCreated, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	if req.Name == "" || len(req.Name) > maxMerchantName {
+		return sharedTypes.MerchantCreated{}, utils.ErrWrongFormat
+	}
+
+	key, prefix, err := utils.GenerateMerchantKey()
+
+	if err != nil {
+		return sharedTypes.MerchantCreated{}, err
+	}
+
+	created, err := app.Merchants.CreateMerchant(ctx, sharedTypes.Merchant{
+		Name:      req.Name,
+		Prefix:    prefix,
+		Hash:      utils.HashAPIKey(key),
+		CreatedBy: adminUID,
+	})
+
+	if err != nil {
+		return sharedTypes.MerchantCreated{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditAdminMerchantCreate, merchantTarget(created.ID), nil, created)
+
+	return sharedTypes.MerchantCreated{Merchant: created, Key: key}, nil
+}
+
+func (app *AdminApp) ListMerchants(ctx context.Context) ([]sharedTypes.Merchant, error) {
+	return app.Merchants.ListMerchants(ctx)
+}
+
+func (app *AdminApp) RevokeMerchant(ctx context.Context, id string) error {
+	found, err := app.Merchants.RevokeMerchant(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditAdminMerchantRevoke, merchantTarget(id), nil, nil)
+
+	return nil
+}
+
+func (app *AdminApp) SetCardID(ctx context.Context, uid, cardID string) error {
+	cardID = strings.TrimSpace(cardID)
+
+	if len(cardID) > maxCardID {
+		return utils.ErrWrongFormat
+	}
+
+	found, err := app.Admin.SetCardID(ctx, uid, cardID)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditAdminCardLink, userTarget(uid), nil, map[string]string{"card_id": cardID})
+
+	return nil
+}
+
+// lockUsers takes the balance locks of two users in uid order, so two
+// operations over the same pair cannot deadlock.
+func lockUsers(ul *sync.Map, first, second string) (func(), error) {
+	a, _ := strconv.ParseInt(first, 10, 64)
+	b, _ := strconv.ParseInt(second, 10, 64)
+
+	if b < a {
+		first, second = second, first
+	}
+
+	uids := []string{first, second}
+	if first == second {
+		uids = uids[:1]
+	}
+
+	locks := []*sync.Mutex{}
+
+	for _, uid := range uids {
+		rawLock, _ := ul.LoadOrStore(uid, &sync.Mutex{})
+		lock, ok := rawLock.(*sync.Mutex)
+
+		if !ok {
+			return nil, errors.New("wrong lock type")
+		}
+
+		locks = append(locks, lock)
+	}
+
+	for _, lock := range locks {
+		lock.Lock()
+	}
+
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+	}, nil
+}
+
+func (app *AdminApp) ListAudit(ctx context.Context, filter sharedTypes.AuditFilter) ([]sharedTypes.AuditEntry, error) {
+	if filter.Limit <= 0 || filter.Limit > app.Cfg.AuditPageLimit {
+		filter.Limit = app.Cfg.AuditPageLimit
+	}
+
+	return app.Auditor.List(ctx, filter)
+}
+
+func (app *AdminApp) VerifyAudit(ctx context.Context) (sharedTypes.AuditVerification, error) {
+	return app.Auditor.Verify(ctx)
+}
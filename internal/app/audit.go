@@ -0,0 +1,228 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	AuditActorSystem    = "system"
+	AuditActorAnonymous = "anonymous"
+)
+
+const (
+	AuditUserRegister        = "user.register"
+	AuditUserLogin           = "user.login"
+	AuditUserWithdraw        = "user.withdraw"
+	AuditUserTransfer        = "user.transfer"
+	AuditUserAccrual         = "user.accrual"
+	AuditUserExpiry          = "user.expiry"
+	AuditUserTierChange      = "user.tier_change"
+	AuditUserDelete          = "user.delete"
+	AuditUserTOTPEnable      = "user.2fa_enable"
+	AuditUserTOTPDisable     = "user.2fa_disable"
+	AuditSessionRevoke       = "session.revoke"
+	AuditOrderUpload         = "order.upload"
+	AuditOrderCancel         = "order.cancel"
+	AuditOrderRefund         = "order.refund"
+	AuditDisputeOpen         = "dispute.open"
+	AuditDisputeResolve      = "dispute.resolve"
+	AuditCampaignReward      = "campaign.reward"
+	AuditHouseholdCreate     = "household.create"
+	AuditHouseholdDissolve   = "household.dissolve"
+	AuditHouseholdInvite     = "household.invite"
+	AuditHouseholdJoin       = "household.join"
+	AuditHouseholdRemove     = "household.remove"
+	AuditHouseholdLimit      = "household.limit"
+	AuditHouseholdWithdraw   = "household.withdraw"
+	AuditHouseholdExpiry     = "household.expiry"
+	AuditPromoRedeem         = "promo.redeem"
+	AuditReferralReward      = "referral.reward"
+	AuditAdminAdjustment     = "admin.adjustment"
+	AuditAdminSuspend        = "admin.suspend"
+	AuditAdminUnsuspend      = "admin.unsuspend"
+	AuditAdminFreeze         = "admin.freeze"
+	AuditAdminUnfreeze       = "admin.unfreeze"
+	AuditAdminMerge          = "admin.merge"
+	AuditAdminAPIKeyCreate   = "admin.apikey_create"
+	AuditAdminAPIKeyRevoke   = "admin.apikey_revoke"
+	AuditAdminMerchantCreate = "admin.merchant_create"
+	AuditAdminMerchantRevoke = "admin.merchant_revoke"
+	AuditAdminCardLink       = "admin.card_link"
+	AuditAdminCampaignCreate = "admin.campaign_create"
+	AuditAdminCampaignEnd    = "admin.campaign_end"
+	AuditAdminPromoCreate    = "admin.promo_create"
+	AuditAdminPromoRevoke    = "admin.promo_revoke"
+)
+
+const (
+	auditVerifyPageSize        = 1000
+	auditTargetUserPrefix      = "user:"
+	auditTargetOrderPrefix     = "order:"
+	auditTargetAPIKeyPrefix    = "apikey:"
+	auditTargetMerchantPrefix  = "merchant:"
+	auditTargetDisputePrefix   = "dispute:"
+	auditTargetCampaignPrefix  = "campaign:"
+	auditTargetPromoPrefix     = "promo:"
+	auditTargetHouseholdPrefix = "household:"
+)
+
+type Auditor struct {
+	Audit  sharedTypes.AuditStorager
+	logger *zap.SugaredLogger
+}
+
+func InitAuditor(Conn *pgxpool.Pool, logger *zap.SugaredLogger) (*Auditor, error) {
+	audit, err := storage.InitAudit(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Auditor{audit, logger}, nil
+}
+
+// Record runs after the audited action has been committed, so a failure here
+// is logged rather than returned to the caller. A nil Auditor records nothing.
+func (a *Auditor) Record(ctx context.Context, action, target string, before, after interface{}) {
+	if a == nil {
+		return
+	}
+
+	client, isRequest := ctx.Value(sharedTypes.ClientKey{}).(sharedTypes.Client)
+
+	partner, isPartner := ctx.Value(sharedTypes.PartnerKey{}).(sharedTypes.Partner)
+	merchantID, isMerchant := ctx.Value(sharedTypes.MerchantKey{}).(string)
+
+	actor, _ := ctx.Value(sharedTypes.UIDKey{}).(string)
+	if actor != "" {
+		actor = auditTargetUserPrefix + actor
+	} else if isPartner {
+		actor = auditTargetAPIKeyPrefix + partner.KeyID
+	} else if isMerchant {
+		actor = auditTargetMerchantPrefix + merchantID
+	} else if isRequest {
+		actor = AuditActorAnonymous
+	} else {
+		actor = AuditActorSystem
+	}
+
+	entry := sharedTypes.AuditEntry{
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		RequestID: client.RequestID,
+		IP:        client.IP,
+		Before:    a.marshal(before),
+		After:     a.marshal(after),
+	}
+
+	err := a.Audit.AppendAudit(ctx, entry)
+
+	if err != nil {
+		a.logger.Errorw("Unable to write audit entry",
+			"action", action,
+			"target", target,
+			"err", err,
+		)
+	}
+}
+
+func (a *Auditor) marshal(value interface{}) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+
+	if err != nil {
+		a.logger.Errorw("Unable to encode audit value", "err", err)
+		return nil
+	}
+
+	return raw
+}
+
+func (a *Auditor) List(ctx context.Context, filter sharedTypes.AuditFilter) ([]sharedTypes.AuditEntry, error) {
+	return a.Audit.ListAudit(ctx, filter)
+}
+
+// Verify walks the whole chain and reports the first entry whose link or
+// digest does not match.
+func (a *Auditor) Verify(ctx context.Context) (sharedTypes.AuditVerification, error) {
+	result := sharedTypes.AuditVerification{Valid: true}
+
+	var afterID int64
+
+	prevHash := ""
+
+	for {
+		page, err := a.Audit.ListAuditChain(ctx, afterID, auditVerifyPageSize)
+
+		if err != nil {
+			return sharedTypes.AuditVerification{}, err
+		}
+
+		for _, entry := range page {
+			result.Checked++
+
+			if entry.PrevHash != prevHash || utils.AuditHash(entry) != entry.Hash {
+				result.Valid = false
+				result.BrokenAt = entry.ID
+
+				return result, nil
+			}
+
+			prevHash = entry.Hash
+		}
+
+		if len(page) < auditVerifyPageSize {
+			return result, nil
+		}
+
+		afterID, err = strconv.ParseInt(page[len(page)-1].ID, 10, 64)
+
+		if err != nil {
+			return sharedTypes.AuditVerification{}, err
+		}
+	}
+}
+
+func userTarget(uid string) string {
+	return auditTargetUserPrefix + uid
+}
+
+func orderTarget(orderID string) string {
+	return auditTargetOrderPrefix + orderID
+}
+
+func apiKeyTarget(id string) string {
+	return auditTargetAPIKeyPrefix + id
+}
+
+func disputeTarget(id string) string {
+	return auditTargetDisputePrefix + id
+}
+
+func merchantTarget(id string) string {
+	return auditTargetMerchantPrefix + id
+}
+
+func campaignTarget(id string) string {
+	return auditTargetCampaignPrefix + id
+}
+
+func promoTarget(id string) string {
+	return auditTargetPromoPrefix + id
+}
+
+func householdTarget(id string) string {
+	return auditTargetHouseholdPrefix + id
+}
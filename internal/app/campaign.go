@@ -0,0 +1,161 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+const maxCampaignName = 100
+
+func (app *AdminApp) CreateCampaign(ctx context.Context, adminUID string, c sharedTypes.Campaign) (sharedTypes.Campaign, error) {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Tier = strings.TrimSpace(c.Tier)
+
+	err := validateCampaign(c, time.Now())
+
+	if err != nil {
+		return sharedTypes.Campaign{}, err
+	}
+
+	c.CreatedBy = adminUID
+
+	created, err := app.Campaigns.CreateCampaign(ctx, c)
+
+	if err != nil {
+		return sharedTypes.Campaign{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditAdminCampaignCreate, campaignTarget(created.ID), nil, created)
+
+	return created, nil
+}
+
+func validateCampaign(c sharedTypes.Campaign, now time.Time) error {
+	if c.Name == "" || len(c.Name) > maxCampaignName {
+		return utils.ErrWrongFormat
+	}
+
+	if !c.EndsAt.After(c.StartsAt) || !c.EndsAt.After(now) {
+		return utils.ErrWrongFormat
+	}
+
+	if c.RewardType != sharedTypes.CampaignRewardFixed && c.RewardType != sharedTypes.CampaignRewardPercent {
+		return utils.ErrWrongFormat
+	}
+
+	if c.RewardValue <= 0 || c.MinAccrual < 0 || c.UserCap < 0 {
+		return utils.ErrWrongFormat
+	}
+
+	return nil
+}
+
+func (app *AdminApp) ListCampaigns(ctx context.Context) ([]sharedTypes.Campaign, error) {
+	return app.Campaigns.ListCampaigns(ctx)
+}
+
+func (app *AdminApp) EndCampaign(ctx context.Context, id string) error {
+	found, err := app.Campaigns.EndCampaign(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditAdminCampaignEnd, campaignTarget(id), nil, nil)
+
+	return nil
+}
+
+// applyCampaigns grants every running campaign the processed order qualifies
+// for. The base accrual is already credited, so failures are only logged.
+func (app *OrderApp) applyCampaigns(ctx context.Context, uid, orderID string, accrual float32) {
+	unlock, err := lockUsers(app.UserLocks, uid, uid)
+
+	if err != nil {
+		app.logCampaignError(orderID, uid, err)
+		return
+	}
+
+	defer unlock()
+
+	campaigns, err := app.Campaigns.ListActiveCampaigns(ctx, uid)
+
+	if err != nil || len(campaigns) == 0 {
+		app.logCampaignError(orderID, uid, err)
+		return
+	}
+
+	subject, err := app.Campaigns.GetRewardSubject(ctx, uid, orderID)
+
+	if err != nil {
+		app.logCampaignError(orderID, uid, err)
+		return
+	}
+
+	for _, c := range campaigns {
+		amount := campaignReward(c, subject, accrual)
+
+		if amount <= 0 {
+			continue
+		}
+
+		reward, granted, err := app.Campaigns.GrantReward(ctx, sharedTypes.CampaignReward{CampaignID: c.ID, UID: uid, OrderID: orderID, Amount: amount}, c.UserCap)
+
+		if err != nil {
+			app.logCampaignError(orderID, uid, err)
+			continue
+		}
+
+		if granted {
+			app.Auditor.Record(ctx, AuditCampaignReward, campaignTarget(c.ID), nil, map[string]interface{}{"uid": uid, "order": orderID, "amount": reward.Amount})
+		}
+	}
+}
+
+func (app *OrderApp) logCampaignError(orderID, uid string, err error) {
+	if err == nil {
+		return
+	}
+
+	app.logger.Errorw("Unable to apply campaigns",
+		"order id", orderID,
+		"uid", uid,
+		"err", err,
+	)
+}
+
+// campaignReward is what the campaign grants for the order's base accrual,
+// trimmed to what is left of the user's cap when the campaigns were listed.
+// GrantReward trims it again under a lock.
+func campaignReward(c sharedTypes.Campaign, subject sharedTypes.RewardSubject, accrual float32) float32 {
+	if c.FirstOrder && !subject.FirstOrder {
+		return 0
+	}
+
+	if c.Tier != "" && c.Tier != subject.Tier {
+		return 0
+	}
+
+	if accrual < c.MinAccrual {
+		return 0
+	}
+
+	reward := c.RewardValue
+	if c.RewardType == sharedTypes.CampaignRewardPercent {
+		reward = accrual * c.RewardValue / 100
+	}
+
+	if c.UserCap > 0 && reward > c.UserCap-c.Granted {
+		reward = c.UserCap - c.Granted
+	}
+
+	return reward
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/mocks"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func Test_ApplyCampaigns(t *testing.T) {
+	fixed := sharedTypes.Campaign{ID: "1", RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50}
+	percent := sharedTypes.Campaign{ID: "2", RewardType: sharedTypes.CampaignRewardPercent, RewardValue: 10}
+
+	tests := []struct {
+		name     string
+		campaign sharedTypes.Campaign
+		subject  sharedTypes.RewardSubject
+		accrual  float32
+		reward   float32
+	}{
+		{
+			name:     "Fixed reward",
+			campaign: fixed,
+			accrual:  200,
+			reward:   50,
+		},
+		{
+			name:     "Percent of the base accrual",
+			campaign: percent,
+			accrual:  200,
+			reward:   20,
+		},
+		{
+			name:     "First order only, first order",
+			campaign: sharedTypes.Campaign{ID: "3", FirstOrder: true, RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50},
+			subject:  sharedTypes.RewardSubject{FirstOrder: true},
+			accrual:  200,
+			reward:   50,
+		},
+		{
+			name:     "First order only, repeat order",
+			campaign: sharedTypes.Campaign{ID: "3", FirstOrder: true, RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50},
+			accrual:  200,
+		},
+		{
+			name:     "Tier matches",
+			campaign: sharedTypes.Campaign{ID: "4", Tier: "gold", RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50},
+			subject:  sharedTypes.RewardSubject{Tier: "gold"},
+			accrual:  200,
+			reward:   50,
+		},
+		{
+			name:     "Other tier",
+			campaign: sharedTypes.Campaign{ID: "4", Tier: "gold", RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50},
+			subject:  sharedTypes.RewardSubject{Tier: "silver"},
+			accrual:  200,
+		},
+		{
+			name:     "Below the minimum accrual",
+			campaign: sharedTypes.Campaign{ID: "5", MinAccrual: 300, RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50},
+			accrual:  200,
+		},
+		{
+			name:     "Trimmed to what is left of the cap",
+			campaign: sharedTypes.Campaign{ID: "6", RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50, UserCap: 100, Granted: 70},
+			accrual:  200,
+			reward:   30,
+		},
+		{
+			name:     "Cap already reached",
+			campaign: sharedTypes.Campaign{ID: "6", RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50, UserCap: 100, Granted: 100},
+			accrual:  200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaigns := mocks.NewCampaignStorager(t)
+			a := OrderApp{Campaigns: campaigns, logger: zap.NewNop().Sugar(), UserLocks: &sync.Map{}}
+
+			campaigns.On("ListActiveCampaigns", mock.Anything, "1").Return([]sharedTypes.Campaign{tt.campaign}, nil).Once()
+			campaigns.On("GetRewardSubject", mock.Anything, "1", "12345678903").Return(tt.subject, nil).Once()
+
+			if tt.reward > 0 {
+				reward := sharedTypes.CampaignReward{CampaignID: tt.campaign.ID, UID: "1", OrderID: "12345678903", Amount: tt.reward}
+				campaigns.On("GrantReward", mock.Anything, reward, tt.campaign.UserCap).Return(reward, true, nil).Once()
+			}
+
+			a.applyCampaigns(context.Background(), "1", "12345678903", tt.accrual)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joeljunstrom/go-luhn"
+	"go.uber.org/zap"
+)
+
+const (
+	DisputeDecisionReassign = "reassign"
+	DisputeDecisionReject   = "reject"
+)
+
+const (
+	DisputeRoleClaimant = "claimant"
+	DisputeRoleHolder   = "holder"
+)
+
+const maxDisputeEvidence = 2000
+
+type DisputeApp struct {
+	Dispute   sharedTypes.DisputeStorager
+	Cfg       *config.Config
+	logger    *zap.SugaredLogger
+	UserLocks *sync.Map
+	Auditor   *Auditor
+}
+
+func InitDisputeApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, ul *sync.Map, auditor *Auditor) (*DisputeApp, error) {
+	dispute, err := storage.InitDispute(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DisputeApp{dispute, cfg, logger, ul, auditor}, nil
+}
+
+func (app *DisputeApp) OpenDispute(ctx context.Context, uid string, req sharedTypes.DisputeRequest) (sharedTypes.Dispute, error) {
+	req.Evidence = strings.TrimSpace(req.Evidence)
+
+	if !luhn.Valid(req.Number) || req.Evidence == "" || len(req.Evidence) > maxDisputeEvidence {
+		return sharedTypes.Dispute{}, utils.ErrWrongFormat
+	}
+
+	d, err := app.Dispute.CreateDispute(ctx, sharedTypes.Dispute{Number: req.Number, ClaimantUID: uid, Evidence: req.Evidence})
+
+	if err != nil {
+		return sharedTypes.Dispute{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditDisputeOpen, disputeTarget(d.ID), nil, map[string]string{"order": d.Number, "holder_uid": d.HolderUID})
+
+	return asParty(d, uid), nil
+}
+
+// ListUserDisputes returns the disputes the user opened and the ones opened
+// against orders they hold.
+func (app *DisputeApp) ListUserDisputes(ctx context.Context, uid string) ([]sharedTypes.Dispute, error) {
+	list, err := app.Dispute.ListUserDisputes(ctx, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return []sharedTypes.Dispute{}, utils.ErrNoData
+	}
+
+	for i := range list {
+		list[i] = asParty(list[i], uid)
+	}
+
+	return list, nil
+}
+
+func (app *DisputeApp) ListDisputes(ctx context.Context, status string) ([]sharedTypes.Dispute, error) {
+	switch status {
+	case "", sharedTypes.DisputeStatusOpen, sharedTypes.DisputeStatusReassigned, sharedTypes.DisputeStatusRejected:
+	default:
+		return nil, utils.ErrWrongFormat
+	}
+
+	return app.Dispute.ListDisputes(ctx, status, app.Cfg.DisputePageLimit)
+}
+
+func (app *DisputeApp) ResolveDispute(ctx context.Context, adminUID, id string, res sharedTypes.DisputeResolution) (sharedTypes.Dispute, error) {
+	res.Note = strings.TrimSpace(res.Note)
+
+	if len(res.Note) > maxAdjustmentComment {
+		return sharedTypes.Dispute{}, utils.ErrWrongFormat
+	}
+
+	var reassign bool
+
+	switch res.Decision {
+	case DisputeDecisionReassign:
+		reassign = true
+	case DisputeDecisionReject:
+	default:
+		return sharedTypes.Dispute{}, utils.ErrWrongFormat
+	}
+
+	d, err := app.Dispute.GetDispute(ctx, id)
+
+	if err != nil {
+		return sharedTypes.Dispute{}, err
+	}
+
+	// points may move between the two parties, so keep their balances still
+	unlock, err := lockUsers(app.UserLocks, d.ClaimantUID, d.HolderUID)
+
+	if err != nil {
+		return sharedTypes.Dispute{}, err
+	}
+
+	defer unlock()
+
+	d.Resolution = res.Note
+	d.ResolvedBy = adminUID
+
+	resolved, err := app.Dispute.ResolveDispute(ctx, d, reassign)
+
+	if err != nil {
+		return sharedTypes.Dispute{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditDisputeResolve, disputeTarget(id), map[string]string{"holder_uid": d.HolderUID},
+		map[string]string{"status": resolved.Status, "order": resolved.Number})
+
+	return resolved, nil
+}
+
+// asParty hides the other side's identity and, from the holder, the evidence.
+func asParty(d sharedTypes.Dispute, uid string) sharedTypes.Dispute {
+	d.Role = DisputeRoleHolder
+
+	if d.ClaimantUID == uid {
+		d.Role = DisputeRoleClaimant
+	} else {
+		d.Evidence = ""
+	}
+
+	d.ClaimantUID = ""
+	d.HolderUID = ""
+
+	return d
+}
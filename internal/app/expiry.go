@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"strconv"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+)
+
+// ListExpiringPoints returns an empty schedule when points never expire.
+func (app *UserApp) ListExpiringPoints(ctx context.Context, uid string) (sharedTypes.ExpiringPoints, error) {
+	expiring := sharedTypes.ExpiringPoints{Schedule: []sharedTypes.PointsExpiry{}}
+
+	if app.Cfg.PointsLifetimeDays <= 0 {
+		return expiring, nil
+	}
+
+	schedule, err := app.Lots.ListExpiringPoints(ctx, uid, app.Cfg.PointsLifetimeDays)
+
+	if err != nil {
+		return sharedTypes.ExpiringPoints{}, err
+	}
+
+	for _, entry := range schedule {
+		expiring.Total += entry.Points
+	}
+
+	expiring.Schedule = schedule
+
+	return expiring, nil
+}
+
+// ExpirePoints walks every user and household pool with expired lots, a batch
+// at a time, and reports how many of them lost points. One that fails is
+// logged and skipped, so one bad account cannot hold back everyone after it.
+func (app *UserApp) ExpirePoints(ctx context.Context) (int, error) {
+	if app.Cfg.PointsLifetimeDays <= 0 {
+		return 0, nil
+	}
+
+	expired := 0
+	after := "0"
+
+	for {
+		uids, err := app.Lots.ListUsersWithExpiredLots(ctx, app.Cfg.PointsLifetimeDays, after, app.Cfg.PointsExpiryBatch)
+
+		if err != nil {
+			return expired, err
+		}
+
+		for _, uid := range uids {
+			adj, err := app.expireUserPoints(ctx, uid)
+
+			if err != nil {
+				app.logger.Errorw("Unable to expire points",
+					"uid", uid,
+					"err", err,
+				)
+
+				continue
+			}
+
+			if adj.Amount == 0 {
+				continue
+			}
+
+			expired++
+
+			app.Auditor.Record(ctx, AuditUserExpiry, userTarget(uid), nil, adj)
+		}
+
+		if len(uids) == 0 || len(uids) < app.Cfg.PointsExpiryBatch {
+			break
+		}
+
+		after = uids[len(uids)-1]
+	}
+
+	pools, err := app.expireHouseholdPoints(ctx)
+
+	return expired + pools, err
+}
+
+func (app *UserApp) expireHouseholdPoints(ctx context.Context) (int, error) {
+	expired := 0
+	after := "0"
+
+	for {
+		ids, err := app.Lots.ListHouseholdsWithExpiredLots(ctx, app.Cfg.PointsLifetimeDays, after, app.Cfg.PointsExpiryBatch)
+
+		if err != nil {
+			return expired, err
+		}
+
+		for _, id := range ids {
+			amount, err := app.Lots.ExpireHouseholdLots(ctx, id, app.Cfg.PointsLifetimeDays)
+
+			if err != nil {
+				app.logger.Errorw("Unable to expire household points",
+					"household", id,
+					"err", err,
+				)
+
+				continue
+			}
+
+			if amount == 0 {
+				continue
+			}
+
+			expired++
+
+			app.Auditor.Record(ctx, AuditHouseholdExpiry, householdTarget(id), nil, map[string]float32{"amount": -amount})
+		}
+
+		if len(ids) == 0 || len(ids) < app.Cfg.PointsExpiryBatch {
+			return expired, nil
+		}
+
+		after = ids[len(ids)-1]
+	}
+}
+
+func (app *UserApp) expireUserPoints(ctx context.Context, uid string) (sharedTypes.Adjustment, error) {
+	unlock, err := lockUsers(app.UserLocks, uid, uid)
+
+	if err != nil {
+		return sharedTypes.Adjustment{}, err
+	}
+
+	defer unlock()
+
+	adj := sharedTypes.Adjustment{
+		UID:     uid,
+		Reason:  AdjustmentReasonExpiry,
+		Comment: "points earned more than " + strconv.Itoa(app.Cfg.PointsLifetimeDays) + " days ago expired",
+	}
+
+	return app.Lots.ExpireLots(ctx, adj, app.Cfg.PointsLifetimeDays)
+}
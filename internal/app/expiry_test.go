@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func Test_ExpirePoints(t *testing.T) {
+	cfg := &config.Config{PointsLifetimeDays: 365, PointsExpiryBatch: 2}
+	lots := mocks.NewLotStorager(t)
+
+	a := UserApp{Lots: lots, Cfg: cfg, logger: zap.NewNop().Sugar(), UserLocks: &sync.Map{}}
+
+	expiry := func(uid string) interface{} {
+		return mock.MatchedBy(func(adj sharedTypes.Adjustment) bool { return adj.UID == uid })
+	}
+
+	lots.On("ListUsersWithExpiredLots", mock.Anything, 365, "0", 2).Return([]string{"1", "2"}, nil).Once()
+	lots.On("ListUsersWithExpiredLots", mock.Anything, 365, "2", 2).Return([]string{"5"}, nil).Once()
+
+	lots.On("ExpireLots", mock.Anything, expiry("1"), 365).Return(sharedTypes.Adjustment{}, errors.New("deadlock detected")).Once()
+	lots.On("ExpireLots", mock.Anything, expiry("2"), 365).Return(sharedTypes.Adjustment{UID: "2", Amount: -10}, nil).Once()
+	lots.On("ExpireLots", mock.Anything, expiry("5"), 365).Return(sharedTypes.Adjustment{UID: "5", Amount: -3}, nil).Once()
+
+	lots.On("ListHouseholdsWithExpiredLots", mock.Anything, 365, "0", 2).Return([]string{"3", "4"}, nil).Once()
+	lots.On("ListHouseholdsWithExpiredLots", mock.Anything, 365, "4", 2).Return([]string{}, nil).Once()
+
+	lots.On("ExpireHouseholdLots", mock.Anything, "3", 365).Return(float32(7), nil).Once()
+	lots.On("ExpireHouseholdLots", mock.Anything, "4", 365).Return(float32(0), nil).Once()
+
+	expired, err := a.ExpirePoints(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+}
+
+func Test_ExpirePointsEmptyPage(t *testing.T) {
+	cfg := &config.Config{PointsLifetimeDays: 365, PointsExpiryBatch: 0}
+	lots := mocks.NewLotStorager(t)
+
+	a := UserApp{Lots: lots, Cfg: cfg, logger: zap.NewNop().Sugar(), UserLocks: &sync.Map{}}
+
+	lots.On("ListUsersWithExpiredLots", mock.Anything, 365, "0", 0).Return([]string{}, nil).Once()
+	lots.On("ListHouseholdsWithExpiredLots", mock.Anything, 365, "0", 0).Return([]string{}, nil).Once()
+
+	expired, err := a.ExpirePoints(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expired)
+}
@@ -0,0 +1,256 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const maxHouseholdName = 100
+
+type HouseholdApp struct {
+	Household sharedTypes.HouseholdStorager
+	Cfg       *config.Config
+	logger    *zap.SugaredLogger
+	UserLocks *sync.Map
+	Auditor   *Auditor
+}
+
+func InitHouseholdApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, ul *sync.Map, auditor *Auditor) (*HouseholdApp, error) {
+	household, err := storage.InitHousehold(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &HouseholdApp{household, cfg, logger, ul, auditor}, nil
+}
+
+func (app *HouseholdApp) CreateHousehold(ctx context.Context, uid string, req sharedTypes.HouseholdRequest) (sharedTypes.Household, error) {
+	req.Name = strings.TrimSpace(req.Name)
+
+	if req.Name == "" || len(req.Name) > maxHouseholdName {
+		return sharedTypes.Household{}, utils.ErrWrongFormat
+	}
+
+	h, err := app.Household.CreateHousehold(ctx, uid, req.Name)
+
+	if err != nil {
+		return sharedTypes.Household{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditHouseholdCreate, householdTarget(h.ID), nil, map[string]string{"name": h.Name, "owner_uid": uid})
+
+	return app.Household.GetHousehold(ctx, uid)
+}
+
+func (app *HouseholdApp) GetHousehold(ctx context.Context, uid string) (sharedTypes.Household, error) {
+	return app.Household.GetHousehold(ctx, uid)
+}
+
+// ownedHousehold returns the household the user owns, or ErrForbidden for
+// plain members.
+func (app *HouseholdApp) ownedHousehold(ctx context.Context, uid string) (sharedTypes.Household, error) {
+	h, err := app.Household.GetHousehold(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.Household{}, err
+	}
+
+	if h.OwnerUID != uid {
+		return sharedTypes.Household{}, utils.ErrForbidden
+	}
+
+	return h, nil
+}
+
+// DissolveHousehold releases the members and pays the pool out to the owner.
+func (app *HouseholdApp) DissolveHousehold(ctx context.Context, uid string) error {
+	h, err := app.ownedHousehold(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockUsers(app.UserLocks, uid, uid)
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	pool, err := app.Household.DissolveHousehold(ctx, h.ID, uid)
+
+	if err != nil {
+		return err
+	}
+
+	app.Auditor.Record(ctx, AuditHouseholdDissolve, householdTarget(h.ID), map[string]interface{}{"balance": h.Balance}, map[string]interface{}{"paid_out": pool, "owner_uid": uid})
+
+	return nil
+}
+
+func (app *HouseholdApp) InviteMember(ctx context.Context, uid string, req sharedTypes.HouseholdInviteRequest) (sharedTypes.HouseholdInvite, error) {
+	req.Login = strings.TrimSpace(req.Login)
+
+	if req.Login == "" {
+		return sharedTypes.HouseholdInvite{}, utils.ErrWrongFormat
+	}
+
+	h, err := app.ownedHousehold(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.HouseholdInvite{}, err
+	}
+
+	invite, err := app.Household.CreateInvite(ctx, h.ID, req.Login)
+
+	if err != nil {
+		return sharedTypes.HouseholdInvite{}, err
+	}
+
+	invite.Household = h.Name
+
+	app.Auditor.Record(ctx, AuditHouseholdInvite, householdTarget(h.ID), nil, map[string]string{"invite": invite.ID, "uid": invite.UID})
+
+	return invite, nil
+}
+
+func (app *HouseholdApp) ListInvites(ctx context.Context, uid string) ([]sharedTypes.HouseholdInvite, error) {
+	list, err := app.Household.ListInvites(ctx, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return []sharedTypes.HouseholdInvite{}, utils.ErrNoData
+	}
+
+	return list, nil
+}
+
+func (app *HouseholdApp) AcceptInvite(ctx context.Context, uid, id string) (sharedTypes.Household, error) {
+	householdID, err := app.Household.AcceptInvite(ctx, uid, id)
+
+	if err != nil {
+		return sharedTypes.Household{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditHouseholdJoin, householdTarget(householdID), nil, map[string]string{"uid": uid})
+
+	return app.Household.GetHousehold(ctx, uid)
+}
+
+func (app *HouseholdApp) DeclineInvite(ctx context.Context, uid, id string) error {
+	found, err := app.Household.DeclineInvite(ctx, uid, id)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// RemoveMember lets the owner remove anyone else and a member leave on their
+// own. The owner leaves by dissolving the household.
+func (app *HouseholdApp) RemoveMember(ctx context.Context, uid, memberUID string) error {
+	h, err := app.Household.GetHousehold(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case memberUID == h.OwnerUID:
+		return utils.ErrHouseholdOwner
+	case uid != h.OwnerUID && uid != memberUID:
+		return utils.ErrForbidden
+	}
+
+	found, err := app.Household.RemoveMember(ctx, h.ID, memberUID)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditHouseholdRemove, householdTarget(h.ID), nil, map[string]string{"uid": memberUID})
+
+	return nil
+}
+
+// SetSpendLimit caps what a member may take from the pool each calendar month;
+// a nil limit lifts the cap. The owner is never capped.
+func (app *HouseholdApp) SetSpendLimit(ctx context.Context, uid, memberUID string, limit *float32) error {
+	if limit != nil && *limit < 0 {
+		return utils.ErrWrongFormat
+	}
+
+	h, err := app.ownedHousehold(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	if memberUID == h.OwnerUID {
+		return utils.ErrWrongFormat
+	}
+
+	found, err := app.Household.SetSpendLimit(ctx, h.ID, memberUID, limit)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditHouseholdLimit, householdTarget(h.ID), nil, map[string]interface{}{"uid": memberUID, "limit": limit})
+
+	return nil
+}
+
+// withdrawHousehold spends from the pool. The storage checks the pool and the
+// member's limit under a lock on the pool row, which is what keeps members
+// spending at the same time on different instances within bounds.
+func (app *UserApp) withdrawHousehold(ctx context.Context, uid, orderID string, amount float32) error {
+	unlock, err := lockUsers(app.UserLocks, uid, uid)
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	pool, err := app.Households.WithdrawHousehold(ctx, uid, orderID, amount)
+
+	if err != nil {
+		return err
+	}
+
+	app.Auditor.Record(ctx, AuditHouseholdWithdraw, householdTarget(pool.ID), nil, map[string]interface{}{
+		"uid":     uid,
+		"order":   orderID,
+		"amount":  amount,
+		"balance": pool.Current,
+	})
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type MerchantApp struct {
+	Merchant  sharedTypes.MerchantStorager
+	Orders    sharedTypes.OrderApper
+	Cfg       *config.Config
+	logger    *zap.SugaredLogger
+	UserLocks *sync.Map
+	Auditor   *Auditor
+}
+
+func InitMerchantApp(Conn *pgxpool.Pool, orders sharedTypes.OrderApper, cfg *config.Config, logger *zap.SugaredLogger, ul *sync.Map, auditor *Auditor) (*MerchantApp, error) {
+	switch cfg.ClawbackPolicy {
+	case sharedTypes.ClawbackPolicyNegative, sharedTypes.ClawbackPolicyFloor, sharedTypes.ClawbackPolicyReject:
+	default:
+		return nil, errors.New("unknown clawback policy " + cfg.ClawbackPolicy)
+	}
+
+	merchant, err := storage.InitMerchant(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &MerchantApp{merchant, orders, cfg, logger, ul, auditor}, nil
+}
+
+// CreateOrder attributes a merchant's order to the customer named by exactly
+// one of login or loyalty card.
+func (app *MerchantApp) CreateOrder(ctx context.Context, order sharedTypes.MerchantOrder) error {
+	order.Login = strings.TrimSpace(order.Login)
+	order.CardID = strings.TrimSpace(order.CardID)
+
+	if (order.Login == "") == (order.CardID == "") || len(order.Goods) == 0 {
+		return utils.ErrWrongFormat
+	}
+
+	uid, suspended, err := app.Merchant.FindCustomer(ctx, order.Login, order.CardID)
+
+	if err != nil {
+		return err
+	}
+
+	if suspended {
+		return utils.ErrSuspended
+	}
+
+	order.UID = uid
+
+	return app.Orders.CreateMerchantOrder(ctx, order)
+}
+
+// RefundOrder claws back the points earned on the refunded share of the order.
+// Without an amount the whole remainder is refunded. Points the customer has
+// already spent are debited or written off according to the clawback policy.
+func (app *MerchantApp) RefundOrder(ctx context.Context, merchantID, number string, req sharedTypes.RefundRequest) (sharedTypes.Refund, error) {
+	if req.Amount < 0 {
+		return sharedTypes.Refund{}, utils.ErrWrongFormat
+	}
+
+	order, err := app.Merchant.GetRefundableOrder(ctx, merchantID, number)
+
+	if err != nil {
+		return sharedTypes.Refund{}, err
+	}
+
+	unlock, err := lockUsers(app.UserLocks, order.UID, order.UID)
+
+	if err != nil {
+		return sharedTypes.Refund{}, err
+	}
+
+	defer unlock()
+
+	// re-read under the lock so a refund that finished meanwhile is seen
+	order, err = app.Merchant.GetRefundableOrder(ctx, merchantID, number)
+
+	if err != nil {
+		return sharedTypes.Refund{}, err
+	}
+
+	refund, err := clawback(order, req.Amount)
+
+	if err != nil {
+		return sharedTypes.Refund{}, err
+	}
+
+	refund.Number = number
+
+	refund, err = app.Merchant.RefundOrder(ctx, refund, order, app.Cfg.ClawbackPolicy)
+
+	if err != nil {
+		return sharedTypes.Refund{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditOrderRefund, orderTarget(number), map[string]interface{}{"net_accrual": order.NetAccrual}, refund)
+
+	return refund, nil
+}
+
+func clawback(order sharedTypes.RefundableOrder, amount float32) (sharedTypes.Refund, error) {
+	if order.Status != sharedTypes.OrderStatusProcessed {
+		return sharedTypes.Refund{}, utils.ErrNotProcessed
+	}
+
+	refund := sharedTypes.Refund{UID: order.UID, Amount: amount, Clawback: order.NetAccrual}
+	remaining := order.Total - order.Refunded
+
+	switch {
+	case amount == 0:
+		if remaining > 0 {
+			refund.Amount = remaining
+		}
+
+		if refund.Amount == 0 && refund.Clawback == 0 {
+			return sharedTypes.Refund{}, utils.ErrRefunded
+		}
+	case order.Total <= 0 || amount > remaining:
+		return sharedTypes.Refund{}, utils.ErrWrongFormat
+	case order.Accrual*amount/order.Total < refund.Clawback:
+		refund.Clawback = order.Accrual * amount / order.Total
+	}
+
+	return refund, nil
+}
@@ -2,49 +2,233 @@ package app
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/T-V-N/gopherstore/internal/config"
 	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
 	"github.com/T-V-N/gopherstore/internal/storage"
 	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joeljunstrom/go-luhn"
 	"go.uber.org/zap"
 )
 
+const purchaseClockSkew = 5 * time.Minute
+
+const (
+	OrderResultAccepted = "accepted"
+	OrderResultUploaded = "already_uploaded"
+	OrderResultConflict = "owned_by_another_user"
+	OrderResultInvalid  = "invalid"
+	OrderResultFailed   = "failed"
+	// OrderResultSkipped marks numbers left untouched because the request
+	// ended before their turn; they can be submitted again.
+	OrderResultSkipped = "skipped"
+)
+
 type OrderApp struct {
-	Order    sharedTypes.OrderStorager
-	Cfg      *config.Config
-	logger   *zap.SugaredLogger
-	RegOrder sharedTypes.OrderRegisterer
+	Order     sharedTypes.OrderStorager
+	Cfg       *config.Config
+	logger    *zap.SugaredLogger
+	RegOrder  sharedTypes.OrderRegisterer
+	Auditor   *Auditor
+	Tiers     sharedTypes.TierStorager
+	Campaigns sharedTypes.CampaignStorager
+	Referrals sharedTypes.ReferralStorager
+	UserLocks *sync.Map
 }
 
-func InitOrderApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, or sharedTypes.OrderRegisterer) (*OrderApp, error) {
+func InitOrderApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, or sharedTypes.OrderRegisterer, auditor *Auditor, ul *sync.Map) (*OrderApp, error) {
 	order, err := storage.InitOrder(Conn)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &OrderApp{order, cfg, logger, or}, nil
+	tiers, err := storage.InitTier(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns, err := storage.InitCampaign(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	referrals, err := storage.InitReferral(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &OrderApp{order, cfg, logger, or, auditor, tiers, campaigns, referrals, ul}, nil
 }
 
 func (app *OrderApp) CreateOrder(ctx context.Context, orderID string, uid string) error {
+	err := app.registerOrder(ctx, orderID, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = app.Order.CreateOrder(ctx, orderID, uid)
+
+	if err != nil {
+		return err
+	}
+
+	app.Auditor.Record(ctx, AuditOrderUpload, orderTarget(orderID), nil, map[string]string{"uid": uid})
+
+	return nil
+}
+
+// CreateOrders uploads each number on its own, so one bad number does not
+// stop the rest of the batch. Every number gets its own timeout, and once the
+// request is gone the remaining numbers are skipped rather than registered
+// with the accrual system without being stored.
+func (app *OrderApp) CreateOrders(ctx context.Context, uid string, numbers []string) []sharedTypes.OrderResult {
+	results := make([]sharedTypes.OrderResult, 0, len(numbers))
+
+	for _, number := range numbers {
+		status := OrderResultSkipped
+
+		if ctx.Err() == nil {
+			status = app.createBatchOrder(ctx, number, uid)
+		}
+
+		results = append(results, sharedTypes.OrderResult{Number: number, Status: status})
+	}
+
+	return results
+}
+
+func (app *OrderApp) createBatchOrder(ctx context.Context, number, uid string) string {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(app.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	err := app.CreateOrder(ctx, number, uid)
+
+	switch {
+	case err == nil:
+		return OrderResultAccepted
+	case errors.Is(err, utils.ErrAlreadyCreated):
+		return OrderResultUploaded
+	case errors.Is(err, utils.ErrDuplicate):
+		return OrderResultConflict
+	case errors.Is(err, utils.ErrWrongFormat):
+		return OrderResultInvalid
+	default:
+		return OrderResultFailed
+	}
+}
+
+func (app *OrderApp) CancelOrder(ctx context.Context, uid, orderID string) error {
+	err := app.Order.CancelOrder(ctx, uid, orderID)
+
+	if err != nil {
+		return err
+	}
+
+	app.Auditor.Record(ctx, AuditOrderCancel, orderTarget(orderID), map[string]string{"uid": uid}, nil)
+
+	return nil
+}
+
+func (app *OrderApp) CreateMerchantOrder(ctx context.Context, order sharedTypes.MerchantOrder) error {
+	return app.createDetailedOrder(ctx, order.UID, order.MerchantID, order.OrderSubmission)
+}
+
+func (app *OrderApp) SubmitOrder(ctx context.Context, uid string, order sharedTypes.OrderSubmission) error {
+	return app.createDetailedOrder(ctx, uid, "", order)
+}
+
+func (app *OrderApp) createDetailedOrder(ctx context.Context, uid, merchantID string, order sharedTypes.OrderSubmission) error {
+	err := validateSubmission(&order, time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	err = app.registerOrder(ctx, order.Number, order.Goods)
+
+	if err != nil {
+		return err
+	}
+
+	order.ExpectedAccrual = expectedAccrual(order, app.Cfg.PendingAccrualRate)
+
+	err = app.Order.CreateDetailedOrder(ctx, uid, merchantID, order)
+
+	if err != nil {
+		return err
+	}
+
+	after := map[string]string{"uid": uid}
+	if merchantID != "" {
+		after["merchant_id"] = merchantID
+	}
+
+	app.Auditor.Record(ctx, AuditOrderUpload, orderTarget(order.Number), nil, after)
+
+	return nil
+}
+
+func (app *OrderApp) registerOrder(ctx context.Context, orderID string, goods []sharedTypes.Good) error {
 	isOrderIDValid := luhn.Valid(orderID)
 
 	if !isOrderIDValid {
 		return utils.ErrWrongFormat
 	}
 
-	err := app.RegOrder.RegisterOrder(ctx, orderID)
+	return app.RegOrder.RegisterOrder(ctx, orderID, goods)
+}
 
-	if err != nil {
-		return err
+// validateSubmission defaults missing quantities to one. A purchase may not
+// be dated in the future beyond a small clock skew.
+func validateSubmission(order *sharedTypes.OrderSubmission, now time.Time) error {
+	if order.Total < 0 {
+		return utils.ErrWrongFormat
 	}
 
-	err = app.Order.CreateOrder(ctx, orderID, uid)
+	if order.PurchasedAt != nil && order.PurchasedAt.After(now.Add(purchaseClockSkew)) {
+		return utils.ErrWrongFormat
+	}
 
-	return err
+	for i := range order.Goods {
+		good := &order.Goods[i]
+
+		if good.Quantity == 0 {
+			good.Quantity = 1
+		}
+
+		if strings.TrimSpace(good.Description) == "" || good.Price < 0 || good.Quantity < 0 {
+			return utils.ErrWrongFormat
+		}
+	}
+
+	return nil
+}
+
+// expectedAccrual estimates the accrual by our own rule, a flat rate of the
+// basket value or of the declared total when there is no basket.
+func expectedAccrual(order sharedTypes.OrderSubmission, rate float32) float32 {
+	value := order.Total
+
+	if len(order.Goods) > 0 {
+		value = 0
+
+		for _, good := range order.Goods {
+			value += good.Price * float32(good.Quantity)
+		}
+	}
+
+	return value * rate / 100
 }
 
 func (app *OrderApp) ListOrders(ctx context.Context, uid string) ([]sharedTypes.Order, error) {
@@ -78,13 +262,56 @@ func (app *OrderApp) GetUnproccessedOrders(ctx context.Context) ([]sharedTypes.O
 func (app *OrderApp) UpdateOrder(ctx context.Context, orderID, status string, accrual float32, user sharedTypes.UserApper) error {
 	uid, err := app.Order.UpdateOrder(ctx, orderID, status, accrual)
 
+	// the owner cancelled the order while its job was in flight
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
 	if err != nil {
 		return err
 	}
 
+	if status != sharedTypes.OrderStatusProcessed {
+		return nil
+	}
+
 	if accrual > 0 {
-		return user.UpdateUser(ctx, uid, orderID, accrual)
+		err = user.UpdateUser(ctx, uid, orderID, accrual, app.tierBonus(ctx, uid, orderID, accrual))
+
+		if err != nil {
+			return err
+		}
+
+		app.applyCampaigns(ctx, uid, orderID, accrual)
 	}
 
+	// a first order that earns nothing still settles the referral
+	app.applyReferral(ctx, uid, orderID, accrual)
+
 	return nil
 }
+
+// tierBonus returns the bonus the owner's tier adds on top of the base
+// accrual. The order is already processed at this point, so a failure only
+// costs the bonus and never the base accrual.
+func (app *OrderApp) tierBonus(ctx context.Context, uid, orderID string, accrual float32) float32 {
+	multiplier, err := app.Tiers.GetMultiplier(ctx, uid)
+
+	if err != nil {
+		app.logger.Errorw("Unable to get tier multiplier",
+			"order id", orderID,
+			"uid", uid,
+			"err", err,
+		)
+
+		return 0
+	}
+
+	bonus := accrual * (multiplier - 1)
+
+	if bonus <= 0 {
+		return 0
+	}
+
+	return bonus
+}
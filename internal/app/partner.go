@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type PartnerApp struct {
+	User   sharedTypes.UserStorager
+	Orders sharedTypes.OrderApper
+	Cfg    *config.Config
+	logger *zap.SugaredLogger
+}
+
+func InitPartnerApp(Conn *pgxpool.Pool, orders sharedTypes.OrderApper, cfg *config.Config, logger *zap.SugaredLogger) (*PartnerApp, error) {
+	user, err := storage.InitUser(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PartnerApp{user, orders, cfg, logger}, nil
+}
+
+func (app *PartnerApp) CreateOrder(ctx context.Context, uid, orderID string) error {
+	_, err := app.activeUser(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	return app.Orders.CreateOrder(ctx, orderID, uid)
+}
+
+func (app *PartnerApp) GetBalance(ctx context.Context, uid string) (sharedTypes.Balance, error) {
+	user, err := app.activeUser(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.Balance{}, err
+	}
+
+	return sharedTypes.Balance{Current: user.CurrentBalance, Withdrawn: user.Withdrawn, Frozen: user.Frozen}, nil
+}
+
+// activeUser resolves the user a partner names in the path. Suspended and
+// merged accounts are refused just like they are for their owners.
+func (app *PartnerApp) activeUser(ctx context.Context, uid string) (sharedTypes.User, error) {
+	if _, err := strconv.ParseInt(uid, 10, 32); err != nil {
+		return sharedTypes.User{}, utils.ErrNotFound
+	}
+
+	user, err := app.User.GetUserByID(ctx, uid)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sharedTypes.User{}, utils.ErrNotFound
+	}
+
+	if err != nil {
+		return sharedTypes.User{}, err
+	}
+
+	if user.Suspended {
+		return sharedTypes.User{}, utils.ErrSuspended
+	}
+
+	return user, nil
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	minPromoCode = 8
+	maxPromoCode = 32
+)
+
+type PromoApp struct {
+	Promo     sharedTypes.PromoStorager
+	Cfg       *config.Config
+	logger    *zap.SugaredLogger
+	UserLocks *sync.Map
+	Auditor   *Auditor
+}
+
+func InitPromoApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, ul *sync.Map, auditor *Auditor) (*PromoApp, error) {
+	promo, err := storage.InitPromo(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PromoApp{promo, cfg, logger, ul, auditor}, nil
+}
+
+// CreatePromoCode generates the code unless the admin picked one. Only its hash
+// is stored, so the response is the one place the plaintext code appears.
+func (app *PromoApp) CreatePromoCode(ctx context.Context, adminUID string, p sharedTypes.PromoCode) (sharedTypes.PromoCode, error) {
+	if p.PerUserLimit == 0 {
+		p.PerUserLimit = 1
+	}
+
+	err := validatePromoCode(p, time.Now())
+
+	if err != nil {
+		return sharedTypes.PromoCode{}, err
+	}
+
+	if p.Code == "" {
+		p.Code, err = utils.GeneratePromoCode()
+
+		if err != nil {
+			return sharedTypes.PromoCode{}, err
+		}
+	}
+
+	p.Hash = utils.HashPromoCode(p.Code, app.Cfg.SecretKey)
+	p.Hint = utils.PromoCodeHint(p.Code)
+	p.CreatedBy = adminUID
+
+	created, err := app.Promo.CreatePromoCode(ctx, p)
+
+	if err != nil {
+		return sharedTypes.PromoCode{}, err
+	}
+
+	logged := created
+	logged.Code = ""
+
+	app.Auditor.Record(ctx, AuditAdminPromoCreate, promoTarget(created.ID), nil, logged)
+
+	return created, nil
+}
+
+func validatePromoCode(p sharedTypes.PromoCode, now time.Time) error {
+	if p.Code != "" {
+		code := utils.NormalizeCode(p.Code)
+
+		if len(code) < minPromoCode || len(code) > maxPromoCode {
+			return utils.ErrWrongFormat
+		}
+
+		for _, r := range code {
+			if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+				return utils.ErrWrongFormat
+			}
+		}
+	}
+
+	if p.Amount <= 0 || p.MaxRedemptions < 0 || p.PerUserLimit < 0 || !p.ExpiresAt.After(now) {
+		return utils.ErrWrongFormat
+	}
+
+	return nil
+}
+
+func (app *PromoApp) ListPromoCodes(ctx context.Context) ([]sharedTypes.PromoCode, error) {
+	return app.Promo.ListPromoCodes(ctx)
+}
+
+func (app *PromoApp) RevokePromoCode(ctx context.Context, id string) error {
+	found, err := app.Promo.RevokePromoCode(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditAdminPromoRevoke, promoTarget(id), nil, nil)
+
+	return nil
+}
+
+func (app *PromoApp) RedeemPromoCode(ctx context.Context, uid, code string) (sharedTypes.PromoRedemption, error) {
+	if utils.NormalizeCode(code) == "" {
+		return sharedTypes.PromoRedemption{}, utils.ErrWrongFormat
+	}
+
+	unlock, err := lockUsers(app.UserLocks, uid, uid)
+
+	if err != nil {
+		return sharedTypes.PromoRedemption{}, err
+	}
+
+	defer unlock()
+
+	redemption, err := app.Promo.RedeemPromoCode(ctx, uid, utils.HashPromoCode(code, app.Cfg.SecretKey))
+
+	if err != nil {
+		return sharedTypes.PromoRedemption{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditPromoRedeem, promoTarget(redemption.CodeID), nil, map[string]interface{}{"uid": uid, "amount": redemption.Amount})
+
+	return redemption, nil
+}
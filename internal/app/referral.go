@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+const (
+	referralCodeAttempts = 3
+	maskedLoginPrefix    = 2
+)
+
+// ListReferrals hands out the user's referral code on first use and lists
+// the users who signed up with it.
+func (app *UserApp) ListReferrals(ctx context.Context, uid string) (sharedTypes.ReferralSummary, error) {
+	code, err := app.referralCode(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.ReferralSummary{}, err
+	}
+
+	list, err := app.Referrals.ListReferrals(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.ReferralSummary{}, err
+	}
+
+	summary := sharedTypes.ReferralSummary{Code: code, Referrals: list}
+
+	for i := range list {
+		list[i].Login = maskLogin(list[i].Login)
+		summary.Earned += list[i].ReferrerBonus
+	}
+
+	return summary, nil
+}
+
+func (app *UserApp) referralCode(ctx context.Context, uid string) (string, error) {
+	code, err := app.Referrals.GetReferralCode(ctx, uid)
+
+	if err != nil || code != "" {
+		return code, err
+	}
+
+	for i := 0; i < referralCodeAttempts; i++ {
+		code, err = utils.GenerateReferralCode()
+
+		if err != nil {
+			return "", err
+		}
+
+		code, err = app.Referrals.SetReferralCode(ctx, uid, code)
+
+		if !errors.Is(err, utils.ErrDuplicate) {
+			return code, err
+		}
+	}
+
+	return "", err
+}
+
+// createReferredUser registers a user who signed up with a referral code. A
+// code shared too widely in one day stops accepting sign-ups until it cools down.
+func (app *UserApp) createReferredUser(ctx context.Context, creds sharedTypes.Credentials) (string, error) {
+	referrer, err := app.Referrals.GetReferrer(ctx, utils.NormalizeCode(creds.ReferralCode))
+
+	if err != nil {
+		return "", err
+	}
+
+	return app.Referrals.CreateReferredUser(ctx, creds, referrer.UID, app.Cfg.ReferralDailyLimit)
+}
+
+func maskLogin(login string) string {
+	runes := []rune(login)
+
+	if len(runes) <= maskedLoginPrefix {
+		return "***"
+	}
+
+	return string(runes[:maskedLoginPrefix]) + "***"
+}
+
+// applyReferral settles the referral of a user whose first order has just been
+// processed. The order itself is already processed, so failures are only logged.
+func (app *OrderApp) applyReferral(ctx context.Context, uid, orderID string, accrual float32) {
+	r, err := app.Referrals.GetPendingReferral(ctx, uid)
+
+	if errors.Is(err, utils.ErrNotFound) {
+		return
+	}
+
+	if err != nil {
+		app.logReferralError(orderID, uid, err)
+		return
+	}
+
+	unlock, err := lockUsers(app.UserLocks, r.ReferrerUID, r.ReferredUID)
+
+	if err != nil {
+		app.logReferralError(orderID, uid, err)
+		return
+	}
+
+	defer unlock()
+
+	reason := referralRejection(app.Cfg.ReferralWindowDays, app.Cfg.ReferralMinAccrual, r, accrual, time.Now())
+
+	if reason != "" {
+		err = app.Referrals.RejectReferral(ctx, r.ID, reason)
+		app.logReferralError(orderID, uid, err)
+
+		return
+	}
+
+	r.OrderID = orderID
+	r.ReferrerBonus = app.Cfg.ReferrerBonus
+	r.RefereeBonus = app.Cfg.RefereeBonus
+
+	r, err = app.Referrals.RewardReferral(ctx, r, app.Cfg.ReferralMaxRewards)
+
+	if err != nil {
+		if !errors.Is(err, utils.ErrNotFound) {
+			app.logReferralError(orderID, uid, err)
+		}
+
+		return
+	}
+
+	if r.Status == sharedTypes.ReferralStatusRewarded {
+		app.Auditor.Record(ctx, AuditReferralReward, userTarget(r.ReferrerUID), nil, map[string]interface{}{
+			"referred_uid":   r.ReferredUID,
+			"order":          orderID,
+			"referrer_bonus": r.ReferrerBonus,
+			"referee_bonus":  r.RefereeBonus,
+		})
+	}
+}
+
+// referralRejection tells why a first order does not earn the referral
+// reward, or returns an empty reason when it does.
+func referralRejection(windowDays int, minAccrual float32, r sharedTypes.Referral, accrual float32, now time.Time) string {
+	if windowDays > 0 && now.After(r.CreatedAt.AddDate(0, 0, windowDays)) {
+		return sharedTypes.ReferralReasonExpired
+	}
+
+	if accrual < minAccrual {
+		return sharedTypes.ReferralReasonMinAccrual
+	}
+
+	return ""
+}
+
+func (app *OrderApp) logReferralError(orderID, uid string, err error) {
+	if err == nil {
+		return
+	}
+
+	app.logger.Errorw("Unable to apply referral",
+		"order id", orderID,
+		"uid", uid,
+		"err", err,
+	)
+}
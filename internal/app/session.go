@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type SessionApp struct {
+	Session sharedTypes.SessionStorager
+	Cfg     *config.Config
+	logger  *zap.SugaredLogger
+	Auditor *Auditor
+}
+
+func InitSessionApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, auditor *Auditor) (*SessionApp, error) {
+	session, err := storage.InitSession(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionApp{session, cfg, logger, auditor}, nil
+}
+
+func (app *SessionApp) ListSessions(ctx context.Context, uid, currentSID string) ([]sharedTypes.Session, error) {
+	list, err := app.Session.ListSessions(ctx, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list {
+		list[i].Current = list[i].ID == currentSID
+	}
+
+	return list, nil
+}
+
+func (app *SessionApp) RevokeSession(ctx context.Context, uid, sid string) error {
+	revoked, err := app.Session.RevokeSession(ctx, uid, sid)
+
+	if err != nil {
+		return err
+	}
+
+	if !revoked {
+		return utils.ErrNotFound
+	}
+
+	app.Auditor.Record(ctx, AuditSessionRevoke, "session:"+sid, nil, map[string]string{"uid": uid})
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/storage"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const tierRecalcJob = "tier_recalculation"
+
+type TierApp struct {
+	Tier    sharedTypes.TierStorager
+	Jobs    sharedTypes.JobStorager
+	Cfg     *config.Config
+	logger  *zap.SugaredLogger
+	Auditor *Auditor
+}
+
+func InitTierApp(Conn *pgxpool.Pool, cfg *config.Config, logger *zap.SugaredLogger, auditor *Auditor) (*TierApp, error) {
+	tier, err := storage.InitTier(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := storage.InitJob(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TierApp{tier, jobs, cfg, logger, auditor}, nil
+}
+
+func (app *TierApp) GetTier(ctx context.Context, uid string) (sharedTypes.TierStatus, error) {
+	tiers, err := app.Tier.ListTiers(ctx)
+
+	if err != nil {
+		return sharedTypes.TierStatus{}, err
+	}
+
+	member, err := app.Tier.GetTierMember(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.TierStatus{}, err
+	}
+
+	current := tierIndex(tiers, member.Tier)
+	if current < 0 {
+		return sharedTypes.TierStatus{}, utils.ErrNotFound
+	}
+
+	status := sharedTypes.TierStatus{
+		Since:          member.Since,
+		Tier:           member.Tier,
+		Multiplier:     tiers[current].Multiplier,
+		RollingAccrual: member.RollingAccrual,
+	}
+
+	if current+1 < len(tiers) {
+		next := tiers[current+1]
+
+		status.NextTier = next.Name
+		status.NextTierAccrual = next.MinAccrual
+
+		if next.MinAccrual > member.RollingAccrual {
+			status.Remaining = next.MinAccrual - member.RollingAccrual
+		}
+	}
+
+	return status, nil
+}
+
+// RecalculateTiers walks all active users and reports how many changed tier.
+func (app *TierApp) RecalculateTiers(ctx context.Context) (int, error) {
+	tiers, err := app.Tier.ListTiers(ctx)
+
+	if err != nil || len(tiers) == 0 {
+		return 0, err
+	}
+
+	grace := time.Duration(app.Cfg.TierDowngradeGraceDays) * 24 * time.Hour
+	changed := 0
+	after := "0"
+
+	for {
+		members, err := app.Tier.ListTierMembers(ctx, after, app.Cfg.TierRecalcBatch)
+
+		if err != nil {
+			return changed, err
+		}
+
+		for _, member := range members {
+			next := nextTier(tiers, member, time.Now(), grace)
+
+			if next == member.Tier {
+				continue
+			}
+
+			err = app.Tier.SetTier(ctx, member.UID, next)
+
+			if err != nil {
+				return changed, err
+			}
+
+			changed++
+
+			app.Auditor.Record(ctx, AuditUserTierChange, userTarget(member.UID), map[string]string{"tier": member.Tier}, map[string]string{"tier": next})
+		}
+
+		if len(members) == 0 || len(members) < app.Cfg.TierRecalcBatch {
+			return changed, nil
+		}
+
+		after = members[len(members)-1].UID
+	}
+}
+
+// RecalculateTiersIfDue recalculates on whichever instance claims the run
+// once the interval has passed since the last finished one. It reports
+// whether it ran.
+func (app *TierApp) RecalculateTiersIfDue(ctx context.Context) (int, bool, error) {
+	release, claimed, err := app.Jobs.ClaimJob(ctx, tierRecalcJob, app.Cfg.TierRecalcInterval)
+
+	if err != nil || !claimed {
+		return 0, false, err
+	}
+
+	changed, err := app.RecalculateTiers(ctx)
+
+	releaseErr := release(err == nil)
+
+	if err != nil {
+		return changed, true, err
+	}
+
+	return changed, true, releaseErr
+}
+
+// nextTier upgrades straight to the highest tier the rolling accrual earns.
+// Downgrades go one tier at a time and only once the current tier has been
+// held for the grace period, so a quiet month does not cost a tier at once.
+func nextTier(tiers []sharedTypes.Tier, member sharedTypes.TierMember, now time.Time, grace time.Duration) string {
+	earned := 0
+
+	for i, tier := range tiers {
+		if member.RollingAccrual >= tier.MinAccrual {
+			earned = i
+		}
+	}
+
+	current := tierIndex(tiers, member.Tier)
+
+	switch {
+	case earned > current:
+		return tiers[earned].Name
+	case earned < current && now.Sub(member.Since) >= grace:
+		return tiers[current-1].Name
+	default:
+		return member.Tier
+	}
+}
+
+func tierIndex(tiers []sharedTypes.Tier, name string) int {
+	for i, tier := range tiers {
+		if tier.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/T-V-N/gopherstore/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var testTiers = []sharedTypes.Tier{
+	{Name: "bronze", MinAccrual: 0, Multiplier: 1},
+	{Name: "silver", MinAccrual: 1000, Multiplier: 1.25},
+	{Name: "gold", MinAccrual: 5000, Multiplier: 1.5},
+}
+
+func Test_NextTier(t *testing.T) {
+	now := time.Now()
+	grace := 90 * 24 * time.Hour
+
+	tests := []struct {
+		name   string
+		member sharedTypes.TierMember
+		want   string
+	}{
+		{
+			name:   "Stays",
+			member: sharedTypes.TierMember{Tier: "silver", RollingAccrual: 1500, Since: now.Add(-grace * 2)},
+			want:   "silver",
+		},
+		{
+			name:   "Upgrades one tier",
+			member: sharedTypes.TierMember{Tier: "bronze", RollingAccrual: 1000, Since: now},
+			want:   "silver",
+		},
+		{
+			name:   "Upgrades straight to the highest tier earned",
+			member: sharedTypes.TierMember{Tier: "bronze", RollingAccrual: 7000, Since: now},
+			want:   "gold",
+		},
+		{
+			name:   "Keeps the tier within the grace period",
+			member: sharedTypes.TierMember{Tier: "gold", RollingAccrual: 100, Since: now.Add(-grace / 2)},
+			want:   "gold",
+		},
+		{
+			name:   "Downgrades one tier after the grace period",
+			member: sharedTypes.TierMember{Tier: "gold", RollingAccrual: 100, Since: now.Add(-grace)},
+			want:   "silver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextTier(testTiers, tt.member, now, grace))
+		})
+	}
+}
+
+func Test_UpdateOrderTierBonus(t *testing.T) {
+	tests := []struct {
+		name       string
+		multiplier float32
+		err        error
+		bonus      float32
+	}{
+		{
+			name:       "Multiplier adds a bonus",
+			multiplier: 1.5,
+			bonus:      50,
+		},
+		{
+			name:       "Base tier adds nothing",
+			multiplier: 1,
+		},
+		{
+			name: "Unknown multiplier costs only the bonus",
+			err:  errors.New("connection lost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := mocks.NewOrderStorager(t)
+			tiers := mocks.NewTierStorager(t)
+			campaigns := mocks.NewCampaignStorager(t)
+			referrals := mocks.NewReferralStorager(t)
+			user := mocks.NewUserApper(t)
+
+			a := OrderApp{Order: order, Tiers: tiers, Campaigns: campaigns, Referrals: referrals, logger: zap.NewNop().Sugar(), UserLocks: &sync.Map{}}
+
+			order.On("UpdateOrder", mock.Anything, "12345678903", sharedTypes.OrderStatusProcessed, float32(100)).Return("1", nil).Once()
+			tiers.On("GetMultiplier", mock.Anything, "1").Return(tt.multiplier, tt.err).Once()
+			user.On("UpdateUser", mock.Anything, "1", "12345678903", float32(100), tt.bonus).Return(nil).Once()
+			campaigns.On("ListActiveCampaigns", mock.Anything, "1").Return([]sharedTypes.Campaign{}, nil).Once()
+			referrals.On("GetPendingReferral", mock.Anything, "1").Return(sharedTypes.Referral{}, utils.ErrNotFound).Once()
+
+			err := a.UpdateOrder(context.Background(), "12345678903", sharedTypes.OrderStatusProcessed, 100, user)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_RecalculateTiersIfDue(t *testing.T) {
+	cfg := &config.Config{TierRecalcInterval: 86400, TierRecalcBatch: 500, TierDowngradeGraceDays: 90}
+
+	t.Run("Not due or claimed elsewhere", func(t *testing.T) {
+		jobs := mocks.NewJobStorager(t)
+		a := TierApp{Tier: mocks.NewTierStorager(t), Jobs: jobs, Cfg: cfg}
+
+		jobs.On("ClaimJob", mock.Anything, tierRecalcJob, cfg.TierRecalcInterval).Return(nil, false, nil).Once()
+
+		changed, ran, err := a.RecalculateTiersIfDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.False(t, ran)
+		assert.Equal(t, 0, changed)
+	})
+
+	t.Run("Claimed run is recorded as finished", func(t *testing.T) {
+		jobs := mocks.NewJobStorager(t)
+		tiers := mocks.NewTierStorager(t)
+		a := TierApp{Tier: tiers, Jobs: jobs, Cfg: cfg}
+
+		var finished []bool
+		release := func(done bool) error {
+			finished = append(finished, done)
+			return nil
+		}
+
+		jobs.On("ClaimJob", mock.Anything, tierRecalcJob, cfg.TierRecalcInterval).Return(release, true, nil).Once()
+		tiers.On("ListTiers", mock.Anything).Return(testTiers, nil).Once()
+		tiers.On("ListTierMembers", mock.Anything, "0", cfg.TierRecalcBatch).
+			Return([]sharedTypes.TierMember{{UID: "1", Tier: "bronze", RollingAccrual: 1200}, {UID: "2", Tier: "silver", RollingAccrual: 1200}}, nil).Once()
+		tiers.On("SetTier", mock.Anything, "1", "silver").Return(nil).Once()
+
+		changed, ran, err := a.RecalculateTiersIfDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, 1, changed)
+		assert.Equal(t, []bool{true}, finished)
+	})
+
+	t.Run("Empty page ends the run", func(t *testing.T) {
+		jobs := mocks.NewJobStorager(t)
+		tiers := mocks.NewTierStorager(t)
+		a := TierApp{Tier: tiers, Jobs: jobs, Cfg: &config.Config{TierRecalcInterval: 86400, TierDowngradeGraceDays: 90}}
+
+		release := func(done bool) error { return nil }
+
+		jobs.On("ClaimJob", mock.Anything, tierRecalcJob, cfg.TierRecalcInterval).Return(release, true, nil).Once()
+		tiers.On("ListTiers", mock.Anything).Return(testTiers, nil).Once()
+		tiers.On("ListTierMembers", mock.Anything, "0", 0).Return([]sharedTypes.TierMember{}, nil).Once()
+
+		changed, ran, err := a.RecalculateTiersIfDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, 0, changed)
+	})
+
+	t.Run("Failed run is not recorded", func(t *testing.T) {
+		jobs := mocks.NewJobStorager(t)
+		tiers := mocks.NewTierStorager(t)
+		a := TierApp{Tier: tiers, Jobs: jobs, Cfg: cfg}
+
+		var finished []bool
+		release := func(done bool) error {
+			finished = append(finished, done)
+			return nil
+		}
+
+		jobs.On("ClaimJob", mock.Anything, tierRecalcJob, cfg.TierRecalcInterval).Return(release, true, nil).Once()
+		tiers.On("ListTiers", mock.Anything).Return(nil, errors.New("connection lost")).Once()
+
+		_, ran, err := a.RecalculateTiersIfDue(context.Background())
+
+		assert.Error(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, []bool{false}, finished)
+	})
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+// TransferBalance gifts points to another user under the same overdraft rules
+// as a withdrawal. The storage re-checks them under row locks, which is what
+// keeps transfers from other instances in line.
+func (app *UserApp) TransferBalance(ctx context.Context, uid string, req sharedTypes.TransferRequest) (sharedTypes.Transfer, error) {
+	req.Login = strings.TrimSpace(req.Login)
+
+	if req.Login == "" || req.Amount < app.Cfg.TransferMinAmount || req.Amount <= 0 {
+		return sharedTypes.Transfer{}, utils.ErrWrongFormat
+	}
+
+	recipientUID, err := app.Transfers.GetRecipient(ctx, req.Login)
+
+	if err != nil {
+		return sharedTypes.Transfer{}, err
+	}
+
+	if recipientUID == uid {
+		return sharedTypes.Transfer{}, utils.ErrWrongFormat
+	}
+
+	unlock, err := lockUsers(app.UserLocks, uid, recipientUID)
+
+	if err != nil {
+		return sharedTypes.Transfer{}, err
+	}
+
+	defer unlock()
+
+	balance, err := app.User.GetBalance(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.Transfer{}, err
+	}
+
+	if balance.Frozen {
+		return sharedTypes.Transfer{}, utils.ErrFrozen
+	}
+
+	if balance.Current-req.Amount < 0 {
+		return sharedTypes.Transfer{}, utils.ErrPaymentError
+	}
+
+	limits := sharedTypes.TransferLimits{Amount: app.Cfg.TransferDailyLimit, Count: app.Cfg.TransferDailyCount}
+	t := sharedTypes.Transfer{SenderUID: uid, RecipientUID: recipientUID, Amount: req.Amount}
+
+	t, err = app.Transfers.TransferPoints(ctx, t, limits)
+
+	if err != nil {
+		return sharedTypes.Transfer{}, err
+	}
+
+	app.Auditor.Record(ctx, AuditUserTransfer, userTarget(uid), balance, map[string]interface{}{
+		"transfer":      t.ID,
+		"recipient_uid": recipientUID,
+		"amount":        t.Amount,
+	})
+
+	t.Direction = sharedTypes.TransferSent
+	t.Login = req.Login
+
+	return t, nil
+}
+
+func (app *UserApp) ListTransfers(ctx context.Context, uid string) ([]sharedTypes.Transfer, error) {
+	list, err := app.Transfers.ListTransfers(ctx, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return []sharedTypes.Transfer{}, utils.ErrNoData
+	}
+
+	return list, nil
+}
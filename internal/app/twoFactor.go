@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/auth"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+func (app *UserApp) LoginTwoFactor(ctx context.Context, challenge, code string) (string, error) {
+	uid, err := auth.ParseChallengeToken(challenge, []byte(app.Cfg.SecretKey))
+
+	if err != nil {
+		return "", err
+	}
+
+	user, err := app.User.GetUserByID(ctx, uid)
+
+	if err != nil {
+		return "", utils.ErrNotAuthorized
+	}
+
+	if user.Suspended {
+		return "", utils.ErrSuspended
+	}
+
+	client, _ := ctx.Value(sharedTypes.ClientKey{}).(sharedTypes.Client)
+	event := sharedTypes.LoginEvent{Login: user.Login, UID: user.UID, IP: client.IP, UserAgent: client.UserAgent}
+
+	err = app.checkLoginAllowed(ctx, &event)
+
+	if err != nil {
+		return "", err
+	}
+
+	ok, err := app.verifySecondFactor(ctx, user, code)
+
+	if err != nil {
+		event.Outcome = LoginOutcomeAborted
+		app.recordLoginEvent(ctx, event)
+
+		return "", err
+	}
+
+	if !ok {
+		event.Outcome = LoginOutcomeFailure
+		app.recordLoginEvent(ctx, event)
+
+		return "", utils.ErrNotAuthorized
+	}
+
+	event.Outcome = LoginOutcomeSuccess
+	app.recordLoginEvent(ctx, event)
+
+	return app.startSession(ctx, user.UID, user.Role)
+}
+
+func (app *UserApp) EnrollTOTP(ctx context.Context, uid string) (sharedTypes.TOTPEnrollment, error) {
+	user, err := app.User.GetUserByID(ctx, uid)
+
+	if err != nil {
+		return sharedTypes.TOTPEnrollment{}, err
+	}
+
+	if user.TOTPEnabled {
+		return sharedTypes.TOTPEnrollment{}, utils.ErrTwoFactorOn
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+
+	if err != nil {
+		return sharedTypes.TOTPEnrollment{}, err
+	}
+
+	err = app.User.SetTOTPSecret(ctx, uid, secret)
+
+	if err != nil {
+		return sharedTypes.TOTPEnrollment{}, err
+	}
+
+	return sharedTypes.TOTPEnrollment{Secret: secret, URI: utils.TOTPURI(app.Cfg.TOTPIssuer, user.Login, secret)}, nil
+}
+
+func (app *UserApp) ConfirmTOTP(ctx context.Context, uid, code string) ([]string, error) {
+	user, err := app.User.GetUserByID(ctx, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, utils.ErrTwoFactorOn
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, utils.ErrTwoFactorOff
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now(), app.Cfg.TOTPSkew)
+
+	if !ok {
+		return nil, utils.ErrInvalidCode
+	}
+
+	codes, err := utils.GenerateRecoveryCodes(app.Cfg.RecoveryCodeCount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, c := range codes {
+		hashes = append(hashes, utils.HashRecoveryCode(c))
+	}
+
+	err = app.User.EnableTOTP(ctx, uid, step, hashes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	app.Auditor.Record(ctx, AuditUserTOTPEnable, userTarget(uid), nil, nil)
+
+	return codes, nil
+}
+
+func (app *UserApp) DisableTOTP(ctx context.Context, uid, code string) error {
+	user, err := app.User.GetUserByID(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return utils.ErrTwoFactorOff
+	}
+
+	ok, err := app.verifySecondFactor(ctx, user, code)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return utils.ErrInvalidCode
+	}
+
+	err = app.User.DisableTOTP(ctx, uid)
+
+	if err != nil {
+		return err
+	}
+
+	app.Auditor.Record(ctx, AuditUserTOTPDisable, userTarget(uid), nil, nil)
+
+	return nil
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code.
+func (app *UserApp) verifySecondFactor(ctx context.Context, user sharedTypes.User, code string) (bool, error) {
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now(), app.Cfg.TOTPSkew)
+
+	if ok {
+		return app.User.UseTOTPStep(ctx, user.UID, step)
+	}
+
+	return app.User.UseRecoveryCode(ctx, user.UID, utils.HashRecoveryCode(code))
+}
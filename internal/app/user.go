@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/T-V-N/gopherstore/internal/auth"
 	"github.com/T-V-N/gopherstore/internal/config"
@@ -15,22 +16,97 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	LoginOutcomeSuccess   = "success"
+	LoginOutcomeFailure   = "failure"
+	LoginOutcomeBlocked   = "blocked"
+	LoginOutcomeChallenge = "challenge"
+	LoginOutcomeSuspended = "suspended"
+	// LoginOutcomeAborted ends an attempt that never got to check the
+	// credentials, so it does not count as a failure.
+	LoginOutcomeAborted = "aborted"
+)
+
 type UserApp struct {
-	User       sharedTypes.UserStorager
-	Withdrawal sharedTypes.WithdrawalStorager
-	Cfg        *config.Config
-	logger     *zap.SugaredLogger
-	UserLocks  *sync.Map
+	User        sharedTypes.UserStorager
+	Withdrawal  sharedTypes.WithdrawalStorager
+	LoginEvents sharedTypes.LoginEventStorager
+	Session     sharedTypes.SessionStorager
+	Adjustment  sharedTypes.AdjustmentStorager
+	Lots        sharedTypes.LotStorager
+	Referrals   sharedTypes.ReferralStorager
+	Transfers   sharedTypes.TransferStorager
+	Households  sharedTypes.HouseholdStorager
+	Cfg         *config.Config
+	logger      *zap.SugaredLogger
+	UserLocks   *sync.Map
+	Hasher      *utils.PasswordHasher
+	Policy      *utils.PasswordPolicy
+	Auditor     *Auditor
 }
 
-func InitUserApp(Conn *pgxpool.Pool, w WithdrawalApp, cfg *config.Config, logger *zap.SugaredLogger, ul *sync.Map) (*UserApp, error) {
+func InitUserApp(Conn *pgxpool.Pool, w WithdrawalApp, cfg *config.Config, logger *zap.SugaredLogger, ul *sync.Map, auditor *Auditor) (*UserApp, error) {
 	user, err := storage.InitUser(Conn)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &UserApp{user, w.Withdrawal, cfg, logger, ul}, nil
+	hasher, err := utils.InitPasswordHasher(cfg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := utils.InitPasswordPolicy(cfg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	loginEvents, err := storage.InitLoginEvent(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := storage.InitSession(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment, err := storage.InitAdjustment(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lots, err := storage.InitLot(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	referrals, err := storage.InitReferral(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := storage.InitTransfer(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	households, err := storage.InitHousehold(Conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserApp{user, w.Withdrawal, loginEvents, session, adjustment, lots, referrals, transfers, households, cfg, logger, ul, hasher, policy, auditor}, nil
 }
 
 func (app *UserApp) Register(ctx context.Context, creds sharedTypes.Credentials) (string, error) {
@@ -40,56 +116,299 @@ func (app *UserApp) Register(ctx context.Context, creds sharedTypes.Credentials)
 		return "", err
 	}
 
-	creds.Password, err = utils.HashPassword(creds.Password)
+	err = app.Policy.Validate(creds)
 
 	if err != nil {
 		return "", err
 	}
 
-	uid, err := app.User.CreateUser(ctx, creds)
+	creds.Password, err = app.Hasher.Hash(ctx, creds.Password)
 
 	if err != nil {
 		return "", err
 	}
 
-	return uid, nil
+	var uid string
+
+	if creds.ReferralCode != "" {
+		uid, err = app.createReferredUser(ctx, creds)
+	} else {
+		uid, err = app.User.CreateUser(ctx, creds)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	app.Auditor.Record(ctx, AuditUserRegister, userTarget(uid), nil, nil)
+
+	return app.startSession(ctx, uid, sharedTypes.RoleUser)
 }
 
-func (app *UserApp) Login(ctx context.Context, creds sharedTypes.Credentials) (string, error) {
+func (app *UserApp) Login(ctx context.Context, creds sharedTypes.Credentials) (sharedTypes.LoginResult, error) {
 	err := utils.ValidateLogPass(creds)
 
 	if err != nil {
-		return "", err
+		return sharedTypes.LoginResult{}, err
+	}
+
+	client, _ := ctx.Value(sharedTypes.ClientKey{}).(sharedTypes.Client)
+	event := sharedTypes.LoginEvent{Login: creds.Login, IP: client.IP, UserAgent: client.UserAgent}
+
+	err = app.checkLoginAllowed(ctx, &event)
+
+	if err != nil {
+		return sharedTypes.LoginResult{}, err
 	}
 
 	user, err := app.User.GetUser(ctx, creds)
 
 	if err != nil {
-		return "", utils.ErrNotAuthorized
+		err = app.Hasher.VerifyDummy(ctx, creds.Password)
+
+		if errors.Is(err, utils.ErrBusy) {
+			event.Outcome = LoginOutcomeAborted
+			app.recordLoginEvent(ctx, event)
+
+			return sharedTypes.LoginResult{}, err
+		}
+
+		event.Outcome = LoginOutcomeFailure
+		app.recordLoginEvent(ctx, event)
+
+		return sharedTypes.LoginResult{}, utils.ErrNotAuthorized
+	}
+
+	event.UID = user.UID
+
+	isPasswordValid, needsRehash, err := app.Hasher.Verify(ctx, creds.Password, user.PasswordHash)
+
+	if errors.Is(err, utils.ErrBusy) {
+		event.Outcome = LoginOutcomeAborted
+		app.recordLoginEvent(ctx, event)
+
+		return sharedTypes.LoginResult{}, err
+	}
+
+	if err != nil || !isPasswordValid {
+		event.Outcome = LoginOutcomeFailure
+		app.recordLoginEvent(ctx, event)
+
+		return sharedTypes.LoginResult{}, utils.ErrNotAuthorized
+	}
+
+	if needsRehash {
+		app.rehashPassword(ctx, user.UID, creds.Password)
+	}
+
+	if user.Suspended {
+		event.Outcome = LoginOutcomeSuspended
+		app.recordLoginEvent(ctx, event)
+
+		return sharedTypes.LoginResult{}, utils.ErrSuspended
+	}
+
+	if user.TOTPEnabled {
+		event.Outcome = LoginOutcomeChallenge
+		app.recordLoginEvent(ctx, event)
+
+		result := sharedTypes.LoginResult{}
+		result.Challenge, err = auth.CreateChallengeToken(user.UID, app.Cfg)
+
+		return result, err
+	}
+
+	event.Outcome = LoginOutcomeSuccess
+	app.recordLoginEvent(ctx, event)
+
+	result := sharedTypes.LoginResult{}
+	result.Token, err = app.startSession(ctx, user.UID, user.Role)
+
+	return result, err
+}
+
+func (app *UserApp) startSession(ctx context.Context, uid, role string) (string, error) {
+	client, _ := ctx.Value(sharedTypes.ClientKey{}).(sharedTypes.Client)
+
+	sid, err := app.Session.CreateSession(ctx, uid, client, app.Cfg.JWTExpireTiming)
+
+	if err != nil {
+		return "", err
+	}
+
+	return auth.CreateToken(uid, sid, role, app.Cfg)
+}
+
+// checkLoginAllowed starts the attempt in event and refuses it while the login
+// or the IP is locked out. Every outcome is then recorded against that attempt.
+func (app *UserApp) checkLoginAllowed(ctx context.Context, event *sharedTypes.LoginEvent) error {
+	started, failures, err := app.LoginEvents.StartLoginAttempt(ctx, *event, app.Cfg.LoginFailureWindow)
+
+	if err != nil {
+		return err
+	}
+
+	*event = started
+
+	retryAfter := app.loginRetryAfter(failures, time.Now())
+
+	if retryAfter > 0 {
+		event.Outcome = LoginOutcomeBlocked
+		app.recordLoginEvent(ctx, *event)
+
+		return utils.NewRetryAfterError(retryAfter)
+	}
+
+	return nil
+}
+
+// loginRetryAfter applies an exponential delay after LoginDelayAfter failures and a
+// fixed lockout after LoginMaxFailures, counted per login and per IP.
+func (app *UserApp) loginRetryAfter(f sharedTypes.LoginFailures, now time.Time) time.Duration {
+	var until time.Time
+
+	if f.LoginCount >= app.Cfg.LoginMaxFailures {
+		until = f.LoginLast.Add(time.Duration(app.Cfg.LoginLockoutDuration) * time.Second)
+	} else if f.LoginCount >= app.Cfg.LoginDelayAfter {
+		until = f.LoginLast.Add(app.loginDelay(f.LoginCount - app.Cfg.LoginDelayAfter))
+	}
+
+	if f.IPCount >= app.Cfg.LoginIPMaxFailures {
+		ipUntil := f.IPLast.Add(time.Duration(app.Cfg.LoginLockoutDuration) * time.Second)
+
+		if ipUntil.After(until) {
+			until = ipUntil
+		}
+	}
+
+	if !until.After(now) {
+		return 0
+	}
+
+	return until.Sub(now)
+}
+
+func (app *UserApp) loginDelay(step int) time.Duration {
+	lockout := time.Duration(app.Cfg.LoginLockoutDuration) * time.Second
+	delay := time.Duration(app.Cfg.LoginBaseDelay) * time.Second
+
+	for i := 0; i < step && delay < lockout; i++ {
+		delay *= 2
+	}
+
+	if delay > lockout {
+		return lockout
+	}
+
+	return delay
+}
+
+func (app *UserApp) recordLoginEvent(ctx context.Context, event sharedTypes.LoginEvent) {
+	err := app.LoginEvents.FinishLoginAttempt(ctx, event)
+
+	if err != nil {
+		app.logger.Warnw("Unable to record login event",
+			"login", event.Login,
+			"outcome", event.Outcome,
+			"err", err,
+		)
 	}
 
-	isPasswordValid := utils.CheckPasswordHash(creds.Password, user.PasswordHash)
+	if event.Outcome == LoginOutcomeBlocked || event.Outcome == LoginOutcomeAborted {
+		return
+	}
 
-	if !isPasswordValid {
-		return "", utils.ErrNotAuthorized
+	target := "login:" + event.Login
+	if event.UID != "" {
+		target = userTarget(event.UID)
 	}
 
-	return auth.CreateToken(user.UID, app.Cfg)
+	app.Auditor.Record(ctx, AuditUserLogin, target, nil, map[string]string{"outcome": event.Outcome})
 }
 
+func (app *UserApp) rehashPassword(ctx context.Context, uid, password string) {
+	hash, err := app.Hasher.Hash(ctx, password)
+
+	if err == nil {
+		err = app.User.UpdatePasswordHash(ctx, uid, hash)
+	}
+
+	if err != nil {
+		app.logger.Warnw("Unable to rehash password",
+			"uid", uid,
+			"err", err,
+		)
+	}
+}
+
+// GetBalance shows the household pool next to the personal balance for
+// household members.
 func (app *UserApp) GetBalance(ctx context.Context, uid string) (sharedTypes.Balance, error) {
 	balance, err := app.User.GetBalance(ctx, uid)
 
-	return balance, err
+	if err != nil {
+		return balance, err
+	}
+
+	household, err := app.Households.GetMemberBalance(ctx, uid)
+
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		return balance, nil
+	case err != nil:
+		return sharedTypes.Balance{}, err
+	}
+
+	balance.Household = &household
+
+	return balance, nil
 }
 
-func (app *UserApp) WithdrawBalance(ctx context.Context, uid, orderID string, amount float32) error {
+func (app *UserApp) ListPendingAccruals(ctx context.Context, uid string) ([]sharedTypes.PendingAccrual, error) {
+	list, err := app.User.ListPendingAccruals(ctx, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return []sharedTypes.PendingAccrual{}, utils.ErrNoData
+	}
+
+	return list, nil
+}
+
+func (app *UserApp) ListAdjustments(ctx context.Context, uid string) ([]sharedTypes.Adjustment, error) {
+	list, err := app.Adjustment.ListAdjustments(ctx, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return []sharedTypes.Adjustment{}, utils.ErrNoData
+	}
+
+	return list, nil
+}
+
+// WithdrawBalance spends from the personal balance unless the member asks for
+// the household pool.
+func (app *UserApp) WithdrawBalance(ctx context.Context, uid, orderID string, amount float32, source string) error {
 	isOrderIDValid := luhn.Valid(orderID)
 
 	if !isOrderIDValid {
 		return utils.ErrWrongFormat
 	}
 
+	switch source {
+	case "", sharedTypes.BalanceSourcePersonal:
+	case sharedTypes.BalanceSourceHousehold:
+		return app.withdrawHousehold(ctx, uid, orderID, amount)
+	default:
+		return utils.ErrWrongFormat
+	}
+
 	rawLock, _ := app.UserLocks.LoadOrStore(uid, &sync.Mutex{})
 	lock, ok := rawLock.(*sync.Mutex)
 
@@ -106,19 +425,26 @@ func (app *UserApp) WithdrawBalance(ctx context.Context, uid, orderID string, am
 		return err
 	}
 
+	if balance.Frozen {
+		return utils.ErrFrozen
+	}
+
 	if balance.Current-amount < 0 {
 		return utils.ErrPaymentError
 	}
 
-	newWithdrawn := balance.Withdrawn + amount
-	newCurrent := balance.Current - amount
+	after, err := app.User.WithdrawBalance(ctx, uid, orderID, amount)
+
+	if err != nil {
+		return err
+	}
 
-	err = app.User.WithdrawBalance(ctx, uid, orderID, amount, newCurrent, newWithdrawn, app.Withdrawal)
+	app.Auditor.Record(ctx, AuditUserWithdraw, userTarget(uid), balance, after)
 
-	return err
+	return nil
 }
 
-func (app *UserApp) UpdateUser(ctx context.Context, uid, orderID string, amount float32) error {
+func (app *UserApp) UpdateUser(ctx context.Context, uid, orderID string, accrual, bonus float32) error {
 	rawLock, _ := app.UserLocks.LoadOrStore(uid, &sync.Mutex{})
 	lock, ok := rawLock.(*sync.Mutex)
 
@@ -129,7 +455,13 @@ func (app *UserApp) UpdateUser(ctx context.Context, uid, orderID string, amount
 	lock.Lock()
 	defer lock.Unlock()
 
-	err := app.User.UpdateUser(ctx, uid, orderID, amount)
+	err := app.User.UpdateUser(ctx, uid, orderID, accrual, bonus)
+
+	if err != nil {
+		return err
+	}
+
+	app.Auditor.Record(ctx, AuditUserAccrual, userTarget(uid), nil, map[string]interface{}{"order": orderID, "amount": accrual + bonus, "bonus": bonus})
 
-	return err
+	return nil
 }
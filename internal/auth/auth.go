@@ -4,30 +4,74 @@ import (
 	"time"
 
 	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
 	"github.com/T-V-N/gopherstore/internal/utils"
 
 	"github.com/dgrijalva/jwt-go/v4"
 )
 
+const PurposeTwoFactor = "2fa"
+
 type UIDKey struct{}
 
 type Claims struct {
 	jwt.StandardClaims
-	UID string
+	UID     string
+	SID     string `json:",omitempty"`
+	Role    string `json:",omitempty"`
+	Purpose string `json:",omitempty"`
 }
 
-func CreateToken(uid string, cfg *config.Config) (string, error) {
+func CreateToken(uid, sid, role string, cfg *config.Config) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: jwt.At(time.Now().Add(time.Duration(cfg.JWTExpireTiming * int64(time.Hour)))),
 			IssuedAt:  jwt.At(time.Now()),
 		},
-		UID: uid,
+		UID:  uid,
+		SID:  sid,
+		Role: role,
+	})
+	return token.SignedString([]byte(cfg.SecretKey))
+}
+
+func CreateChallengeToken(uid string, cfg *config.Config) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: jwt.At(time.Now().Add(time.Duration(cfg.TwoFactorTimeout) * time.Second)),
+			IssuedAt:  jwt.At(time.Now()),
+		},
+		UID:     uid,
+		Purpose: PurposeTwoFactor,
 	})
 	return token.SignedString([]byte(cfg.SecretKey))
 }
 
-func ParseToken(token string, key []byte) (string, error) {
+func ParseToken(token string, key []byte) (*Claims, error) {
+	claims, err := parseClaims(token, key)
+
+	if err != nil || claims.Purpose != "" || claims.SID == "" {
+		return nil, utils.ErrNotAuthorized
+	}
+
+	if claims.Role == "" {
+		claims.Role = sharedTypes.RoleUser
+	}
+
+	return claims, nil
+}
+
+func ParseChallengeToken(token string, key []byte) (string, error) {
+	claims, err := parseClaims(token, key)
+
+	if err != nil || claims.Purpose != PurposeTwoFactor {
+		return "", utils.ErrNotAuthorized
+	}
+
+	return claims.UID, nil
+}
+
+func parseClaims(token string, key []byte) (*Claims, error) {
 	parsedToken, err := jwt.ParseWithClaims(token, &Claims{}, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return "", utils.ErrNotAuthorized
@@ -36,11 +80,11 @@ func ParseToken(token string, key []byte) (string, error) {
 	})
 
 	if err != nil {
-		return "", utils.ErrNotAuthorized
+		return nil, utils.ErrNotAuthorized
 	}
 
 	if claims, ok := parsedToken.Claims.(*Claims); ok && parsedToken.Valid {
-		return claims.UID, nil
+		return claims, nil
 	}
-	return "", utils.ErrNotAuthorized
+	return nil, utils.ErrNotAuthorized
 }
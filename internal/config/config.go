@@ -1,23 +1,69 @@
 package config
 
 import (
+	"errors"
 	"flag"
 
 	"github.com/caarlos0/env/v6"
 )
 
 type Config struct {
-	RunAddress           string `env:"RUN_ADDRESS" envDefault:":8080"`
-	DatabaseURI          string `env:"DATABASE_URI"`
-	AccrualSystemAddress string `env:"ACCRUAL_SYSTEM_ADDRESS" envDefault:"http://127.0.0.1:8888"`
-	JWTExpireTiming      int64  `env:"JWT_EXPIRE_TIMING" envDefault:"10000"`
-	SecretKey            string `env:"SECRET_KEY" envDefault:"secret"`
-	MigrationsPath       string `env:"MIGRATIONS_PATH" envDefault:"migrations"`
-	CompressLevel        int    `env:"COMPRESS_LEVEL" envDefault:"5"`
-	CheckOrderDelay      uint   `env:"CHECK_ORDER_DELAY" envDefault:"10"`
-	CheckOrderInterval   uint   `env:"CHECK_ORDER_INTERVAL" envDefault:"10"`
-	WorkerLimit          int    `env:"WORKER_LIMIT" envDefault:"10"`
-	ContextCancelTimeout int    `env:"CONTEXT_CANCEL_AMOUNT" envDefault:"10"`
+	RunAddress             string `env:"RUN_ADDRESS" envDefault:":8080"`
+	DatabaseURI            string `env:"DATABASE_URI"`
+	AccrualSystemAddress   string `env:"ACCRUAL_SYSTEM_ADDRESS" envDefault:"http://127.0.0.1:8888"`
+	JWTExpireTiming        int64  `env:"JWT_EXPIRE_TIMING" envDefault:"10000"`
+	SecretKey              string `env:"SECRET_KEY" envDefault:"secret"`
+	MigrationsPath         string `env:"MIGRATIONS_PATH" envDefault:"migrations"`
+	CompressLevel          int    `env:"COMPRESS_LEVEL" envDefault:"5"`
+	CheckOrderDelay        uint   `env:"CHECK_ORDER_DELAY" envDefault:"10"`
+	CheckOrderInterval     uint   `env:"CHECK_ORDER_INTERVAL" envDefault:"10"`
+	WorkerLimit            int    `env:"WORKER_LIMIT" envDefault:"10"`
+	ContextCancelTimeout   int    `env:"CONTEXT_CANCEL_AMOUNT" envDefault:"10"`
+	PasswordMinLength      int    `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordMaxLength      int    `env:"PASSWORD_MAX_LENGTH" envDefault:"128"`
+	BreachedPasswordList   string `env:"BREACHED_PASSWORD_LIST"`
+	Argon2Time             uint32 `env:"ARGON2_TIME" envDefault:"2"`
+	Argon2Memory           uint32 `env:"ARGON2_MEMORY" envDefault:"19456"`
+	Argon2Threads          uint8  `env:"ARGON2_THREADS" envDefault:"1"`
+	Argon2KeyLength        uint32 `env:"ARGON2_KEY_LENGTH" envDefault:"32"`
+	Argon2SaltLength       uint32 `env:"ARGON2_SALT_LENGTH" envDefault:"16"`
+	HashConcurrency        int    `env:"HASH_CONCURRENCY" envDefault:"4"`
+	TrustProxyHeaders      bool   `env:"TRUST_PROXY_HEADERS" envDefault:"false"`
+	LoginFailureWindow     uint   `env:"LOGIN_FAILURE_WINDOW" envDefault:"900"`
+	LoginDelayAfter        int    `env:"LOGIN_DELAY_AFTER" envDefault:"3"`
+	LoginBaseDelay         uint   `env:"LOGIN_BASE_DELAY" envDefault:"1"`
+	LoginMaxFailures       int    `env:"LOGIN_MAX_FAILURES" envDefault:"10"`
+	LoginLockoutDuration   uint   `env:"LOGIN_LOCKOUT_DURATION" envDefault:"900"`
+	LoginIPMaxFailures     int    `env:"LOGIN_IP_MAX_FAILURES" envDefault:"50"`
+	TOTPIssuer             string `env:"TOTP_ISSUER" envDefault:"Gophermart"`
+	TOTPSkew               int    `env:"TOTP_SKEW" envDefault:"1"`
+	TwoFactorTimeout       int64  `env:"TWO_FACTOR_TIMEOUT" envDefault:"300"`
+	RecoveryCodeCount      int    `env:"RECOVERY_CODE_COUNT" envDefault:"10"`
+	AdminSearchLimit       int    `env:"ADMIN_SEARCH_LIMIT" envDefault:"50"`
+	AdminRecentOrders      int    `env:"ADMIN_RECENT_ORDERS" envDefault:"20"`
+	AuditPageLimit         int    `env:"AUDIT_PAGE_LIMIT" envDefault:"100"`
+	PartnerRateLimit       int    `env:"PARTNER_RATE_LIMIT" envDefault:"60"`
+	PartnerRateWindow      uint   `env:"PARTNER_RATE_WINDOW" envDefault:"60"`
+	OrderBatchLimit        int    `env:"ORDER_BATCH_LIMIT" envDefault:"100"`
+	DisputePageLimit       int    `env:"DISPUTE_PAGE_LIMIT" envDefault:"100"`
+	ClawbackPolicy         string `env:"CLAWBACK_POLICY" envDefault:"negative"`
+	PointsLifetimeDays     int    `env:"POINTS_LIFETIME_DAYS" envDefault:"365"`
+	PointsExpiryInterval   uint   `env:"POINTS_EXPIRY_INTERVAL" envDefault:"3600"`
+	PointsExpiryBatch      int    `env:"POINTS_EXPIRY_BATCH" envDefault:"100"`
+	TierRecalcInterval     uint   `env:"TIER_RECALC_INTERVAL" envDefault:"86400"`
+	TierRecalcBatch        int    `env:"TIER_RECALC_BATCH" envDefault:"500"`
+	TierDowngradeGraceDays int    `env:"TIER_DOWNGRADE_GRACE_DAYS" envDefault:"90"`
+
+	ReferrerBonus      float32 `env:"REFERRER_BONUS" envDefault:"100"`
+	RefereeBonus       float32 `env:"REFEREE_BONUS" envDefault:"50"`
+	ReferralMinAccrual float32 `env:"REFERRAL_MIN_ACCRUAL" envDefault:"10"`
+	ReferralWindowDays int     `env:"REFERRAL_WINDOW_DAYS" envDefault:"30"`
+	ReferralMaxRewards int     `env:"REFERRAL_MAX_REWARDS" envDefault:"20"`
+	ReferralDailyLimit int     `env:"REFERRAL_DAILY_LIMIT" envDefault:"10"`
+	TransferMinAmount  float32 `env:"TRANSFER_MIN_AMOUNT" envDefault:"10"`
+	TransferDailyLimit float32 `env:"TRANSFER_DAILY_LIMIT" envDefault:"1000"`
+	TransferDailyCount int     `env:"TRANSFER_DAILY_COUNT" envDefault:"5"`
+	PendingAccrualRate float32 `env:"PENDING_ACCRUAL_RATE" envDefault:"5"`
 }
 
 func Init() (*Config, error) {
@@ -33,5 +79,13 @@ func Init() (*Config, error) {
 	flag.StringVar(&cfg.AccrualSystemAddress, "r", cfg.AccrualSystemAddress, "accrual system address")
 	flag.Parse()
 
+	if cfg.PointsExpiryBatch <= 0 {
+		return nil, errors.New("POINTS_EXPIRY_BATCH must be positive")
+	}
+
+	if cfg.TierRecalcBatch <= 0 {
+		return nil, errors.New("TIER_RECALC_BATCH must be positive")
+	}
+
 	return cfg, nil
 }
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+func (h *UserHandler) HandleExportData(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	data, err := h.app.ExportData(ctx, uid)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Content-Disposition", `attachment; filename="gophermart-data.json"`)
+
+	err = json.NewEncoder(w).Encode(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.AccountDeletion{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil || req.Password == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.DeleteAccount(ctx, uid, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, utils.ErrInvalidCode):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrHouseholdOwner):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrBusy):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
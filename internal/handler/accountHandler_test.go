@@ -0,0 +1,145 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleExportData(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	user := mocks.NewUserStorage(t)
+
+	a := app.UserApp{User: user, Cfg: cfg}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	user.On("ExportUserData", mock.Anything, "1337").Return(sharedTypes.UserData{
+		Profile:     sharedTypes.Profile{UID: "1337", Login: "tester"},
+		Orders:      []sharedTypes.Order{{Number: "12345678903", Status: "PROCESSED", Accrual: 500}},
+		Withdrawals: []sharedTypes.Withdrawal{},
+		Sessions:    []sharedTypes.Session{{ID: "1", IP: "10.0.0.1"}},
+		LoginEvents: []sharedTypes.LoginEvent{},
+	}, nil).Once()
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1337")
+	request = request.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	hn.HandleExportData(w, request)
+
+	var data sharedTypes.UserData
+	json.NewDecoder(w.Body).Decode(&data)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "tester", data.Profile.Login)
+	assert.Equal(t, 1, len(data.Orders))
+	assert.Equal(t, 1, len(data.Sessions))
+}
+
+func Test_HandleDeleteAccount(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	hasher, _ := utils.InitPasswordHasher(cfg)
+
+	hash, _ := hasher.Hash(context.Background(), "password")
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.TOTPCode(secret, time.Now())
+
+	plainUser := sharedTypes.User{UID: "1", Login: "tester", PasswordHash: hash}
+	twoFactorUser := sharedTypes.User{UID: "1", Login: "tester", PasswordHash: hash, TOTPSecret: secret, TOTPEnabled: true}
+
+	tests := []struct {
+		name       string
+		request    sharedTypes.AccountDeletion
+		user       sharedTypes.User
+		lookup     bool
+		deleted    bool
+		statusCode int
+	}{
+		{
+			name:       "Missing password",
+			request:    sharedTypes.AccountDeletion{},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Wrong password",
+			request:    sharedTypes.AccountDeletion{Password: "wrong"},
+			user:       plainUser,
+			lookup:     true,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Second factor required",
+			request:    sharedTypes.AccountDeletion{Password: "password", Code: "000000x"},
+			user:       twoFactorUser,
+			lookup:     true,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Deleted with second factor",
+			request:    sharedTypes.AccountDeletion{Password: "password", Code: code},
+			user:       twoFactorUser,
+			lookup:     true,
+			deleted:    true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Deleted",
+			request:    sharedTypes.AccountDeletion{Password: "password"},
+			user:       plainUser,
+			lookup:     true,
+			deleted:    true,
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := mocks.NewUserStorage(t)
+
+			a := app.UserApp{User: user, Cfg: cfg, Hasher: hasher}
+			hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+			if tt.lookup {
+				user.On("GetUserByID", mock.Anything, "1").Return(tt.user, nil).Once()
+			}
+
+			if tt.user.TOTPEnabled {
+				user.On("UseTOTPStep", mock.Anything, "1", mock.Anything).Return(true, nil).Maybe()
+				user.On("UseRecoveryCode", mock.Anything, "1", mock.Anything).Return(false, nil).Maybe()
+			}
+
+			if tt.deleted {
+				user.On("DeleteUser", mock.Anything, "1").Return("2", nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodDelete, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleDeleteAccount(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,598 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type AdminHandler struct {
+	app    sharedTypes.AdminApper
+	Cfg    *config.Config
+	logger *zap.SugaredLogger
+}
+
+func InitAdminHandler(a sharedTypes.AdminApper, cfg *config.Config, logger *zap.SugaredLogger) *AdminHandler {
+	return &AdminHandler{a, cfg, logger}
+}
+
+func (h *AdminHandler) HandleSearchUsers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	login := r.URL.Query().Get("login")
+	if login == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.app.SearchUsers(ctx, login)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid := chi.URLParam(r, "uid")
+	if _, err := strconv.ParseInt(uid, 10, 32); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.app.GetUser(ctx, uid)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(detail)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleGetOrder(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	number := chi.URLParam(r, "number")
+	if _, err := strconv.ParseInt(number, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.app.GetOrder(ctx, number)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(order)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleCreateAdjustment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	adminUID, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	uid := chi.URLParam(r, "uid")
+	if _, err := strconv.ParseInt(uid, 10, 32); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	adj := sharedTypes.Adjustment{}
+	err := json.NewDecoder(r.Body).Decode(&adj)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	adj.UID = uid
+
+	created, err := h.app.CreateAdjustment(ctx, adminUID, adj)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrPaymentError):
+			http.Error(w, err.Error(), http.StatusPaymentRequired)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(created)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleMergeUsers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	adminUID, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.MergeRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	merge, err := h.app.MergeUsers(ctx, adminUID, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrMerged):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(merge)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	adminUID, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.APIKeyRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.app.CreateAPIKey(ctx, adminUID, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(created)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	list, err := h.app.ListAPIKeys(ctx)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	id := chi.URLParam(r, "id")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.RevokeAPIKey(ctx, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *AdminHandler) HandleCreateMerchant(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	adminUID, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.MerchantRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.app.CreateMerchant(ctx, adminUID, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(created)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleListMerchants(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	list, err := h.app.ListMerchants(ctx)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleRevokeMerchant(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	id := chi.URLParam(r, "id")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.RevokeMerchant(ctx, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *AdminHandler) HandleCreateCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	adminUID, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.Campaign{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.app.CreateCampaign(ctx, adminUID, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(created)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleListCampaigns(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	list, err := h.app.ListCampaigns(ctx)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleEndCampaign stops a campaign early; rewards already granted stay.
+func (h *AdminHandler) HandleEndCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	id := chi.URLParam(r, "id")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.EndCampaign(ctx, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleSetCardID links a loyalty card to the user; an empty card_id unlinks it.
+func (h *AdminHandler) HandleSetCardID(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid := chi.URLParam(r, "uid")
+	if _, err := strconv.ParseInt(uid, 10, 32); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	req := sharedTypes.CardRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.SetCardID(ctx, uid, req.CardID)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrDuplicate):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleSuspension suspends the user on POST and reinstates them on DELETE.
+func (h *AdminHandler) HandleSuspension(w http.ResponseWriter, r *http.Request) {
+	h.handleRestriction(w, r, h.app.SetSuspended)
+}
+
+// HandleFreeze freezes redemptions on POST and lifts the freeze on DELETE.
+func (h *AdminHandler) HandleFreeze(w http.ResponseWriter, r *http.Request) {
+	h.handleRestriction(w, r, h.app.SetFrozen)
+}
+
+func (h *AdminHandler) handleRestriction(w http.ResponseWriter, r *http.Request, set func(context.Context, string, bool) error) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid := chi.URLParam(r, "uid")
+	if _, err := strconv.ParseInt(uid, 10, 32); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err := set(ctx, uid, r.Method == http.MethodPost)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *AdminHandler) HandleListAudit(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	query := r.URL.Query()
+	filter := sharedTypes.AuditFilter{
+		Actor:  query.Get("actor"),
+		Action: query.Get("action"),
+		Target: query.Get("target"),
+	}
+
+	var err error
+
+	if v := query.Get("before_id"); v != "" {
+		filter.BeforeID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if v := query.Get("limit"); v != "" {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	list, err := h.app.ListAudit(ctx, filter)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AdminHandler) HandleVerifyAudit(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.app.VerifyAudit(r.Context())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(verification)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
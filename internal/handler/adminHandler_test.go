@@ -0,0 +1,824 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/auth"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/middleware"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleSearchUsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		query      string
+		statusCode int
+		found      int
+	}{
+		{
+			name:       "Regular user is forbidden",
+			role:       sharedTypes.RoleUser,
+			query:      "?login=test",
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Missing login",
+			role:       sharedTypes.RoleSupport,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Found by login",
+			role:       sharedTypes.RoleAdmin,
+			query:      "?login=test",
+			statusCode: http.StatusOK,
+			found:      2,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	admin := mocks.NewAdminStorager(t)
+
+	a := app.AdminApp{Admin: admin, Cfg: cfg}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+	guarded := middleware.RequireRole(sharedTypes.RoleSupport, sharedTypes.RoleAdmin)(http.HandlerFunc(hn.HandleSearchUsers))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.found > 0 {
+				admin.On("SearchUsers", mock.Anything, "test", cfg.AdminSearchLimit).Return([]sharedTypes.UserSummary{
+					{UID: "1", Login: "tester", Role: sharedTypes.RoleUser},
+					{UID: "2", Login: "tester2", Role: sharedTypes.RoleUser},
+				}, nil).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			ctx := context.WithValue(request.Context(), sharedTypes.RoleKey{}, tt.role)
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			guarded.ServeHTTP(w, request)
+
+			var list []sharedTypes.UserSummary
+			json.NewDecoder(w.Body).Decode(&list)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.found, len(list))
+		})
+	}
+}
+
+func Test_AuthUsesStoredRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		stored     string
+		statusCode int
+	}{
+		{
+			name:       "Admin keeps access",
+			stored:     sharedTypes.RoleAdmin,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Demoted admin loses access with a live token",
+			stored:     sharedTypes.RoleUser,
+			statusCode: http.StatusForbidden,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	sessions := mocks.NewSessionStorager(t)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	guarded := middleware.InitAuth(cfg, sessions)(middleware.RequireRole(sharedTypes.RoleAdmin)(ok))
+
+	token, _ := auth.CreateToken("1", "7", sharedTypes.RoleAdmin, cfg)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions.On("TouchSession", mock.Anything, "7", "1").Return(tt.stored, true, nil).Once()
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+
+			w := httptest.NewRecorder()
+			guarded.ServeHTTP(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleGetUser(t *testing.T) {
+	type mockSettings struct {
+		isNeeded bool
+		result   []interface{}
+	}
+
+	tests := []struct {
+		name       string
+		uid        string
+		statusCode int
+		mockData   mockSettings
+	}{
+		{
+			name:       "User with orders",
+			uid:        "1",
+			statusCode: http.StatusOK,
+			mockData: mockSettings{isNeeded: true, result: []interface{}{sharedTypes.UserDetail{
+				UserSummary:  sharedTypes.UserSummary{UID: "1", Login: "tester", Balance: sharedTypes.Balance{Current: 500}},
+				RecentOrders: []sharedTypes.Order{{Number: "12345678903", Status: "PROCESSED"}},
+			}, nil}},
+		},
+		{
+			name:       "Unknown user",
+			uid:        "2",
+			statusCode: http.StatusNotFound,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{sharedTypes.UserDetail{}, utils.ErrNotFound}},
+		},
+		{
+			name:       "Malformed uid",
+			uid:        "abc",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	admin := mocks.NewAdminStorager(t)
+
+	a := app.AdminApp{Admin: admin, Cfg: cfg}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockData.isNeeded {
+				admin.On("GetUserDetail", mock.Anything, tt.uid, cfg.AdminRecentOrders).Return(tt.mockData.result...).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uid", tt.uid)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			hn.HandleGetUser(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleGetOrder(t *testing.T) {
+	type mockSettings struct {
+		isNeeded bool
+		result   []interface{}
+	}
+
+	tests := []struct {
+		name       string
+		number     string
+		statusCode int
+		owner      string
+		mockData   mockSettings
+	}{
+		{
+			name:       "Order found",
+			number:     "12345678903",
+			statusCode: http.StatusOK,
+			owner:      "tester",
+			mockData: mockSettings{isNeeded: true, result: []interface{}{sharedTypes.OrderOwner{
+				Order: sharedTypes.Order{Number: "12345678903", Status: "NEW"},
+				UID:   "1",
+				Login: "tester",
+			}, nil}},
+		},
+		{
+			name:       "Unknown order",
+			number:     "9278923470",
+			statusCode: http.StatusNotFound,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{sharedTypes.OrderOwner{}, utils.ErrNotFound}},
+		},
+		{
+			name:       "Malformed number",
+			number:     "12a",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	admin := mocks.NewAdminStorager(t)
+
+	a := app.AdminApp{Admin: admin, Cfg: cfg}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockData.isNeeded {
+				admin.On("GetOrder", mock.Anything, tt.number).Return(tt.mockData.result...).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("number", tt.number)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			hn.HandleGetOrder(w, request)
+
+			var order sharedTypes.OrderOwner
+			json.NewDecoder(w.Body).Decode(&order)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.owner, order.Login)
+		})
+	}
+}
+
+func Test_HandleCreateAdjustment(t *testing.T) {
+	type mockSettings struct {
+		isNeeded bool
+		result   []interface{}
+	}
+
+	tests := []struct {
+		name       string
+		uid        string
+		adjustment sharedTypes.Adjustment
+		statusCode int
+		mockData   mockSettings
+	}{
+		{
+			name:       "Goodwill credit",
+			uid:        "1",
+			adjustment: sharedTypes.Adjustment{Amount: 100, Reason: "goodwill", Comment: "late delivery"},
+			statusCode: http.StatusCreated,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{sharedTypes.Adjustment{ID: "1", Amount: 100, Reason: "goodwill"}, nil}},
+		},
+		{
+			name:       "Correction below zero",
+			uid:        "1",
+			adjustment: sharedTypes.Adjustment{Amount: -1000, Reason: "correction", Comment: "duplicate accrual"},
+			statusCode: http.StatusPaymentRequired,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{sharedTypes.Adjustment{}, utils.ErrPaymentError}},
+		},
+		{
+			name:       "Unknown reason",
+			uid:        "1",
+			adjustment: sharedTypes.Adjustment{Amount: 100, Reason: "because", Comment: "late delivery"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Missing comment",
+			uid:        "1",
+			adjustment: sharedTypes.Adjustment{Amount: 100, Reason: "goodwill", Comment: "  "},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Zero amount",
+			uid:        "1",
+			adjustment: sharedTypes.Adjustment{Reason: "goodwill", Comment: "late delivery"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	adjustment := mocks.NewAdjustmentStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	var userLocks sync.Map
+
+	a := app.AdminApp{Adjustment: adjustment, Cfg: cfg, UserLocks: &userLocks, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockData.isNeeded {
+				expected := tt.adjustment
+				expected.UID = tt.uid
+				expected.AdminUID = "42"
+
+				adjustment.On("CreateAdjustment", mock.Anything, expected).Return(tt.mockData.result...).Once()
+			}
+
+			if tt.statusCode == http.StatusCreated {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Actor == "user:42" && e.Action == app.AuditAdminAdjustment && e.Target == "user:"+tt.uid
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.adjustment)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uid", tt.uid)
+
+			ctx := context.WithValue(request.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, sharedTypes.UIDKey{}, "42")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleCreateAdjustment(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleListAudit(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		filter     sharedTypes.AuditFilter
+		statusCode int
+	}{
+		{
+			name:       "Default limit",
+			query:      "?target=user:1",
+			filter:     sharedTypes.AuditFilter{Target: "user:1", Limit: 100},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Limit is capped",
+			query:      "?action=user.withdraw&before_id=50&limit=100000",
+			filter:     sharedTypes.AuditFilter{Action: "user.withdraw", BeforeID: 50, Limit: 100},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Malformed cursor",
+			query:      "?before_id=abc",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.AdminApp{Auditor: &app.Auditor{Audit: audit}, Cfg: cfg}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode == http.StatusOK {
+				audit.On("ListAudit", mock.Anything, tt.filter).Return([]sharedTypes.AuditEntry{{ID: "1"}}, nil).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			w := httptest.NewRecorder()
+			hn.HandleListAudit(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleVerifyAudit(t *testing.T) {
+	chain := func() []sharedTypes.AuditEntry {
+		entries := []sharedTypes.AuditEntry{
+			{ID: "1", Actor: "anonymous", Action: "user.register", Target: "user:1"},
+			{ID: "2", Actor: "user:1", Action: "order.upload", Target: "order:12345678903", After: []byte(`{"uid":"1"}`)},
+			{ID: "3", Actor: "user:1", Action: "user.withdraw", Target: "user:1", Before: []byte(`{"current":500}`), After: []byte(`{"current":100}`)},
+		}
+
+		prev := ""
+		for i := range entries {
+			entries[i].CreatedAt = time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC)
+			entries[i].PrevHash = prev
+			entries[i].Hash = utils.AuditHash(entries[i])
+			prev = entries[i].Hash
+		}
+
+		return entries
+	}
+
+	tampered := chain()
+	tampered[2].After = []byte(`{"current":400}`)
+
+	unlinked := chain()
+	unlinked = append(unlinked[:1], unlinked[2:]...)
+
+	tests := []struct {
+		name    string
+		entries []sharedTypes.AuditEntry
+		want    sharedTypes.AuditVerification
+	}{
+		{
+			name:    "Intact chain",
+			entries: chain(),
+			want:    sharedTypes.AuditVerification{Valid: true, Checked: 3},
+		},
+		{
+			name:    "Edited entry",
+			entries: tampered,
+			want:    sharedTypes.AuditVerification{Valid: false, Checked: 3, BrokenAt: "3"},
+		},
+		{
+			name:    "Deleted entry",
+			entries: unlinked,
+			want:    sharedTypes.AuditVerification{Valid: false, Checked: 2, BrokenAt: "3"},
+		},
+	}
+	cfg, _ := InitTestConfig()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := mocks.NewAuditStorager(t)
+			audit.On("ListAuditChain", mock.Anything, int64(0), mock.Anything).Return(tt.entries, nil).Once()
+
+			a := app.AdminApp{Auditor: &app.Auditor{Audit: audit}, Cfg: cfg}
+			hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			w := httptest.NewRecorder()
+			hn.HandleVerifyAudit(w, request)
+
+			var got sharedTypes.AuditVerification
+			json.NewDecoder(w.Body).Decode(&got)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_HandleSuspension(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		uid        string
+		found      bool
+		statusCode int
+		action     string
+	}{
+		{
+			name:       "Suspend user",
+			method:     http.MethodPost,
+			uid:        "1",
+			found:      true,
+			statusCode: http.StatusOK,
+			action:     app.AuditAdminSuspend,
+		},
+		{
+			name:       "Reinstate user",
+			method:     http.MethodDelete,
+			uid:        "1",
+			found:      true,
+			statusCode: http.StatusOK,
+			action:     app.AuditAdminUnsuspend,
+		},
+		{
+			name:       "Unknown user",
+			method:     http.MethodPost,
+			uid:        "2",
+			statusCode: http.StatusNotFound,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	admin := mocks.NewAdminStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.AdminApp{Admin: admin, Cfg: cfg, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin.On("SetSuspended", mock.Anything, tt.uid, tt.method == http.MethodPost).Return(tt.found, nil).Once()
+
+			if tt.action != "" {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == tt.action && e.Target == "user:"+tt.uid
+				})).Return(nil).Once()
+			}
+
+			request := httptest.NewRequest(tt.method, "/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uid", tt.uid)
+			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			hn.HandleSuspension(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleFreeze(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	admin := mocks.NewAdminStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.AdminApp{Admin: admin, Cfg: cfg, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	admin.On("SetFrozen", mock.Anything, "1", true).Return(true, nil).Once()
+	audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+		return e.Action == app.AuditAdminFreeze && e.Target == "user:1"
+	})).Return(nil).Once()
+
+	request := httptest.NewRequest(http.MethodPost, "/", nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("uid", "1")
+	request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	hn.HandleFreeze(w, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func Test_HandleMergeUsers(t *testing.T) {
+	type mockSettings struct {
+		isNeeded bool
+		result   []interface{}
+	}
+
+	tests := []struct {
+		name       string
+		request    sharedTypes.MergeRequest
+		statusCode int
+		mockData   mockSettings
+	}{
+		{
+			name:       "Accounts merged",
+			request:    sharedTypes.MergeRequest{SourceUID: "2", TargetUID: "1"},
+			statusCode: http.StatusOK,
+			mockData: mockSettings{isNeeded: true, result: []interface{}{sharedTypes.UserMerge{
+				ID: "1", SourceUID: "2", TargetUID: "1", MovedOrders: 3, MovedBalance: 150,
+			}, nil}},
+		},
+		{
+			name:       "Source already merged",
+			request:    sharedTypes.MergeRequest{SourceUID: "3", TargetUID: "1"},
+			statusCode: http.StatusConflict,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{sharedTypes.UserMerge{}, utils.ErrMerged}},
+		},
+		{
+			name:       "Unknown target",
+			request:    sharedTypes.MergeRequest{SourceUID: "2", TargetUID: "99"},
+			statusCode: http.StatusNotFound,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{sharedTypes.UserMerge{}, utils.ErrNotFound}},
+		},
+		{
+			name:       "Same account",
+			request:    sharedTypes.MergeRequest{SourceUID: "1", TargetUID: "1"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Malformed uid",
+			request:    sharedTypes.MergeRequest{SourceUID: "x", TargetUID: "1"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	admin := mocks.NewAdminStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	var userLocks sync.Map
+
+	a := app.AdminApp{Admin: admin, Cfg: cfg, UserLocks: &userLocks, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockData.isNeeded {
+				expected := sharedTypes.UserMerge{SourceUID: tt.request.SourceUID, TargetUID: tt.request.TargetUID, AdminUID: "42"}
+				admin.On("MergeUsers", mock.Anything, expected).Return(tt.mockData.result...).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditAdminMerge && e.Target == "user:"+tt.request.TargetUID
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "42")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleMergeUsers(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleCreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    sharedTypes.APIKeyRequest
+		statusCode int
+	}{
+		{
+			name:       "Key created",
+			request:    sharedTypes.APIKeyRequest{Name: "pos", Scopes: []string{sharedTypes.ScopeOrdersWrite, sharedTypes.ScopeOrdersWrite}},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Unknown scope",
+			request:    sharedTypes.APIKeyRequest{Name: "pos", Scopes: []string{"users:delete"}},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "No scopes",
+			request:    sharedTypes.APIKeyRequest{Name: "pos"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Missing name",
+			request:    sharedTypes.APIKeyRequest{Scopes: []string{sharedTypes.ScopeBalanceRead}},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	keys := mocks.NewAPIKeyStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.AdminApp{Keys: keys, Cfg: cfg, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode == http.StatusCreated {
+				keys.On("CreateAPIKey", mock.Anything, mock.MatchedBy(func(k sharedTypes.APIKey) bool {
+					return k.Name == "pos" && k.CreatedBy == "42" && len(k.Scopes) == 1 && k.RateLimit == cfg.PartnerRateLimit && k.Hash != ""
+				})).Return(func(_ context.Context, k sharedTypes.APIKey) sharedTypes.APIKey {
+					k.ID = "7"
+					return k
+				}, nil).Once()
+
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditAdminAPIKeyCreate && e.Target == "apikey:7" && !bytes.Contains(e.After, []byte("gm_"))
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "42")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleCreateAPIKey(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusCreated {
+				var created sharedTypes.APIKeyCreated
+				json.NewDecoder(w.Body).Decode(&created)
+
+				prefix, ok := utils.APIKeyPrefix(created.Key)
+				assert.True(t, ok)
+				assert.Equal(t, created.Prefix, prefix)
+			}
+		})
+	}
+}
+
+func Test_HandleRevokeAPIKey(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	keys := mocks.NewAPIKeyStorager(t)
+
+	a := app.AdminApp{Keys: keys, Cfg: cfg}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	keys.On("RevokeAPIKey", mock.Anything, "7").Return(true, nil).Once()
+	keys.On("RevokeAPIKey", mock.Anything, "8").Return(false, nil).Once()
+
+	for id, statusCode := range map[string]int{"7": http.StatusOK, "8": http.StatusNotFound, "x": http.StatusBadRequest} {
+		request := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		hn.HandleRevokeAPIKey(w, request)
+
+		assert.Equal(t, statusCode, w.Code, id)
+	}
+}
+
+func Test_HandleCreateCampaign(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	end := start.Add(14 * 24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		request    sharedTypes.Campaign
+		stored     error
+		statusCode int
+	}{
+		{
+			name:       "Campaign created",
+			request:    sharedTypes.Campaign{Name: "Holiday boost", StartsAt: start, EndsAt: end, Tier: "gold", RewardType: sharedTypes.CampaignRewardPercent, RewardValue: 20, UserCap: 500},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Unknown tier",
+			request:    sharedTypes.Campaign{Name: "Holiday boost", StartsAt: start, EndsAt: end, Tier: "diamond", RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50},
+			stored:     utils.ErrWrongFormat,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Ends before it starts",
+			request:    sharedTypes.Campaign{Name: "Holiday boost", StartsAt: end, EndsAt: start, RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Unknown reward type",
+			request:    sharedTypes.Campaign{Name: "Holiday boost", StartsAt: start, EndsAt: end, RewardType: "double", RewardValue: 2},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Negative cap",
+			request:    sharedTypes.Campaign{Name: "Holiday boost", StartsAt: start, EndsAt: end, RewardType: sharedTypes.CampaignRewardFixed, RewardValue: 50, UserCap: -1},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	campaigns := mocks.NewCampaignStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.AdminApp{Campaigns: campaigns, Cfg: cfg, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode == http.StatusCreated || tt.stored != nil {
+				campaigns.On("CreateCampaign", mock.Anything, mock.MatchedBy(func(c sharedTypes.Campaign) bool {
+					return c.Name == tt.request.Name && c.Tier == tt.request.Tier && c.CreatedBy == "42" && c.EndsAt.Equal(end)
+				})).Return(func(_ context.Context, c sharedTypes.Campaign) sharedTypes.Campaign {
+					c.ID = "3"
+					return c
+				}, tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusCreated {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditAdminCampaignCreate && e.Target == "campaign:3"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "42")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleCreateCampaign(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleEndCampaign(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	campaigns := mocks.NewCampaignStorager(t)
+
+	a := app.AdminApp{Campaigns: campaigns, Cfg: cfg}
+	hn := handler.InitAdminHandler(&a, cfg, &zap.SugaredLogger{})
+
+	campaigns.On("EndCampaign", mock.Anything, "3").Return(true, nil).Once()
+	campaigns.On("EndCampaign", mock.Anything, "4").Return(false, nil).Once()
+
+	for id, statusCode := range map[string]int{"3": http.StatusOK, "4": http.StatusNotFound, "x": http.StatusBadRequest} {
+		request := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+		hn.HandleEndCampaign(w, request)
+
+		assert.Equal(t, statusCode, w.Code, id)
+	}
+}
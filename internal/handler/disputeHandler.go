@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type DisputeHandler struct {
+	app    sharedTypes.DisputeApper
+	Cfg    *config.Config
+	logger *zap.SugaredLogger
+}
+
+func InitDisputeHandler(a sharedTypes.DisputeApper, cfg *config.Config, logger *zap.SugaredLogger) *DisputeHandler {
+	return &DisputeHandler{a, cfg, logger}
+}
+
+func (h *DisputeHandler) HandleOpenDispute(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.DisputeRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	dispute, err := h.app.OpenDispute(ctx, uid, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrDuplicate):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(dispute)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DisputeHandler) HandleListUserDisputes(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	list, err := h.app.ListUserDisputes(ctx, uid)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNoData):
+			http.Error(w, "No content", http.StatusNoContent)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DisputeHandler) HandleListDisputes(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	list, err := h.app.ListDisputes(ctx, r.URL.Query().Get("status"))
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DisputeHandler) HandleResolveDispute(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	adminUID, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	id := chi.URLParam(r, "id")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	res := sharedTypes.DisputeResolution{}
+	err := json.NewDecoder(r.Body).Decode(&res)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	dispute, err := h.app.ResolveDispute(ctx, adminUID, id, res)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrDisputeClosed), errors.Is(err, utils.ErrDisputeStale):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrPaymentError):
+			http.Error(w, err.Error(), http.StatusPaymentRequired)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(dispute)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
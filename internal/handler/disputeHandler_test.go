@@ -0,0 +1,215 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleOpenDispute(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    sharedTypes.DisputeRequest
+		stored     error
+		statusCode int
+	}{
+		{
+			name:       "Dispute opened",
+			request:    sharedTypes.DisputeRequest{Number: "12345678903", Evidence: "receipt photo attached to ticket"},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Dispute already open",
+			request:    sharedTypes.DisputeRequest{Number: "12345678903", Evidence: "again"},
+			stored:     utils.ErrDuplicate,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Unknown order",
+			request:    sharedTypes.DisputeRequest{Number: "49927398716", Evidence: "receipt"},
+			stored:     utils.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Missing evidence",
+			request:    sharedTypes.DisputeRequest{Number: "12345678903", Evidence: "  "},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Invalid order number",
+			request:    sharedTypes.DisputeRequest{Number: "12345678901", Evidence: "receipt"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	dispute := mocks.NewDisputeStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.DisputeApp{Dispute: dispute, Cfg: cfg, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitDisputeHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode != http.StatusUnprocessableEntity {
+				expected := sharedTypes.Dispute{Number: tt.request.Number, ClaimantUID: "1", Evidence: tt.request.Evidence}
+				stored := expected
+				stored.ID, stored.HolderUID, stored.Status = "3", "2", sharedTypes.DisputeStatusOpen
+
+				dispute.On("CreateDispute", mock.Anything, expected).Return(stored, tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusCreated {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditDisputeOpen && e.Target == "dispute:3"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleOpenDispute(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusCreated {
+				var d sharedTypes.Dispute
+				json.NewDecoder(w.Body).Decode(&d)
+
+				assert.Equal(t, app.DisputeRoleClaimant, d.Role)
+				assert.Empty(t, d.HolderUID)
+			}
+		})
+	}
+}
+
+func Test_HandleListUserDisputes(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	dispute := mocks.NewDisputeStorager(t)
+
+	a := app.DisputeApp{Dispute: dispute, Cfg: cfg}
+	hn := handler.InitDisputeHandler(&a, cfg, &zap.SugaredLogger{})
+
+	dispute.On("ListUserDisputes", mock.Anything, "2").Return([]sharedTypes.Dispute{
+		{ID: "3", Number: "12345678903", ClaimantUID: "1", HolderUID: "2", Evidence: "receipt", Status: sharedTypes.DisputeStatusReassigned},
+	}, nil).Once()
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "2")
+	request = request.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	hn.HandleListUserDisputes(w, request)
+
+	var list []sharedTypes.Dispute
+	json.NewDecoder(w.Body).Decode(&list)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []sharedTypes.Dispute{
+		{ID: "3", Number: "12345678903", Role: app.DisputeRoleHolder, Status: sharedTypes.DisputeStatusReassigned},
+	}, list)
+}
+
+func Test_HandleResolveDispute(t *testing.T) {
+	open := sharedTypes.Dispute{ID: "3", Number: "12345678903", ClaimantUID: "1", HolderUID: "2", Status: sharedTypes.DisputeStatusOpen}
+
+	tests := []struct {
+		name       string
+		resolution sharedTypes.DisputeResolution
+		reassign   bool
+		stored     error
+		statusCode int
+	}{
+		{
+			name:       "Order reassigned",
+			resolution: sharedTypes.DisputeResolution{Decision: app.DisputeDecisionReassign, Note: "receipt matches"},
+			reassign:   true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Dispute rejected",
+			resolution: sharedTypes.DisputeResolution{Decision: app.DisputeDecisionReject},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Already resolved",
+			resolution: sharedTypes.DisputeResolution{Decision: app.DisputeDecisionReject},
+			stored:     utils.ErrDisputeClosed,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Holder spent the points",
+			resolution: sharedTypes.DisputeResolution{Decision: app.DisputeDecisionReassign},
+			reassign:   true,
+			stored:     utils.ErrPaymentError,
+			statusCode: http.StatusPaymentRequired,
+		},
+		{
+			name:       "Unknown decision",
+			resolution: sharedTypes.DisputeResolution{Decision: "split"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	dispute := mocks.NewDisputeStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	var userLocks sync.Map
+
+	a := app.DisputeApp{Dispute: dispute, Cfg: cfg, UserLocks: &userLocks, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitDisputeHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode != http.StatusUnprocessableEntity {
+				dispute.On("GetDispute", mock.Anything, "3").Return(open, nil).Once()
+				dispute.On("ResolveDispute", mock.Anything, mock.MatchedBy(func(d sharedTypes.Dispute) bool {
+					return d.ID == "3" && d.ResolvedBy == "42" && d.Resolution == tt.resolution.Note
+				}), tt.reassign).Return(open, tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditDisputeResolve && e.Target == "dispute:3"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.resolution)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "3")
+			ctx := context.WithValue(request.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, sharedTypes.UIDKey{}, "42")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleResolveDispute(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
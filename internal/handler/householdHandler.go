@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type HouseholdHandler struct {
+	app    sharedTypes.HouseholdApper
+	Cfg    *config.Config
+	logger *zap.SugaredLogger
+}
+
+func InitHouseholdHandler(a sharedTypes.HouseholdApper, cfg *config.Config, logger *zap.SugaredLogger) *HouseholdHandler {
+	return &HouseholdHandler{a, cfg, logger}
+}
+
+func (h *HouseholdHandler) HandleCreateHousehold(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.HouseholdRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	household, err := h.app.CreateHousehold(ctx, uid, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrHouseholdTaken):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(household)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *HouseholdHandler) HandleGetHousehold(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	household, err := h.app.GetHousehold(ctx, uid)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(household)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleDissolveHousehold pays the pool out to the owner and releases the members.
+func (h *HouseholdHandler) HandleDissolveHousehold(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	err := h.app.DissolveHousehold(ctx, uid)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *HouseholdHandler) HandleInviteMember(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.HouseholdInviteRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	invite, err := h.app.InviteMember(ctx, uid, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, utils.ErrHouseholdTaken):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrDuplicate):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(invite)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *HouseholdHandler) HandleListInvites(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	list, err := h.app.ListInvites(ctx, uid)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNoData):
+			http.Error(w, err.Error(), http.StatusNoContent)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *HouseholdHandler) HandleAcceptInvite(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	id := chi.URLParam(r, "id")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	household, err := h.app.AcceptInvite(ctx, uid, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrHouseholdTaken):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(household)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *HouseholdHandler) HandleDeclineInvite(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	id := chi.URLParam(r, "id")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.DeclineInvite(ctx, uid, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleRemoveMember removes a member; members pass their own uid to leave.
+func (h *HouseholdHandler) HandleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	memberUID := chi.URLParam(r, "uid")
+	if _, err := strconv.ParseInt(memberUID, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.RemoveMember(ctx, uid, memberUID)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, utils.ErrHouseholdOwner):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *HouseholdHandler) HandleSetSpendLimit(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	memberUID := chi.URLParam(r, "uid")
+	if _, err := strconv.ParseInt(memberUID, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	req := sharedTypes.SpendLimitRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.SetSpendLimit(ctx, uid, memberUID, req.Limit)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
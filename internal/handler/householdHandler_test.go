@@ -0,0 +1,322 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleCreateHousehold(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    sharedTypes.HouseholdRequest
+		created    error
+		statusCode int
+	}{
+		{
+			name:       "Household created",
+			request:    sharedTypes.HouseholdRequest{Name: " Smiths "},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Empty name",
+			request:    sharedTypes.HouseholdRequest{Name: "  "},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Already in a household",
+			request:    sharedTypes.HouseholdRequest{Name: "Smiths"},
+			created:    utils.ErrHouseholdTaken,
+			statusCode: http.StatusConflict,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	households := mocks.NewHouseholdStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.HouseholdApp{Household: households, Cfg: cfg, UserLocks: &sync.Map{}, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitHouseholdHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			household := sharedTypes.Household{ID: "3", Name: "Smiths", OwnerUID: "1"}
+
+			if tt.statusCode != http.StatusUnprocessableEntity {
+				households.On("CreateHousehold", mock.Anything, "1", "Smiths").Return(household, tt.created).Once()
+			}
+
+			if tt.statusCode == http.StatusCreated {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditHouseholdCreate && e.Target == "household:3"
+				})).Return(nil).Once()
+
+				household.Members = []sharedTypes.HouseholdMember{{UID: "1", Login: "grandpa", Owner: true}}
+				households.On("GetHousehold", mock.Anything, "1").Return(household, nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			request = request.WithContext(context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1"))
+
+			w := httptest.NewRecorder()
+			hn.HandleCreateHousehold(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusCreated {
+				got := sharedTypes.Household{}
+				json.NewDecoder(w.Body).Decode(&got)
+
+				assert.Equal(t, "Smiths", got.Name)
+				assert.Len(t, got.Members, 1)
+			}
+		})
+	}
+}
+
+func Test_HandleRemoveMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		uid        string
+		memberUID  string
+		found      bool
+		statusCode int
+	}{
+		{
+			name:       "Owner removes a member",
+			uid:        "1",
+			memberUID:  "2",
+			found:      true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Member leaves",
+			uid:        "2",
+			memberUID:  "2",
+			found:      true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Member removes someone else",
+			uid:        "2",
+			memberUID:  "5",
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Owner cannot leave",
+			uid:        "1",
+			memberUID:  "1",
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Not a member",
+			uid:        "1",
+			memberUID:  "7",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Bad uid",
+			uid:        "1",
+			memberUID:  "grandma",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	households := mocks.NewHouseholdStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.HouseholdApp{Household: households, Cfg: cfg, UserLocks: &sync.Map{}, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitHouseholdHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode != http.StatusBadRequest {
+				households.On("GetHousehold", mock.Anything, tt.uid).Return(sharedTypes.Household{ID: "3", Name: "Smiths", OwnerUID: "1"}, nil).Once()
+			}
+
+			if tt.statusCode == http.StatusOK || tt.statusCode == http.StatusNotFound {
+				households.On("RemoveMember", mock.Anything, "3", tt.memberUID).Return(tt.found, nil).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditHouseholdRemove && e.Target == "household:3"
+				})).Return(nil).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uid", tt.memberUID)
+			ctx := context.WithValue(request.Context(), chi.RouteCtxKey, rctx)
+			request = request.WithContext(context.WithValue(ctx, sharedTypes.UIDKey{}, tt.uid))
+
+			w := httptest.NewRecorder()
+			hn.HandleRemoveMember(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleSetSpendLimit(t *testing.T) {
+	limit := float32(200)
+	negative := float32(-1)
+
+	tests := []struct {
+		name       string
+		uid        string
+		limit      *float32
+		statusCode int
+	}{
+		{
+			name:       "Limit set",
+			uid:        "1",
+			limit:      &limit,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Limit lifted",
+			uid:        "1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Negative limit",
+			uid:        "1",
+			limit:      &negative,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Not the owner",
+			uid:        "2",
+			limit:      &limit,
+			statusCode: http.StatusForbidden,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	households := mocks.NewHouseholdStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.HouseholdApp{Household: households, Cfg: cfg, UserLocks: &sync.Map{}, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitHouseholdHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode != http.StatusUnprocessableEntity {
+				households.On("GetHousehold", mock.Anything, tt.uid).Return(sharedTypes.Household{ID: "3", Name: "Smiths", OwnerUID: "1"}, nil).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				households.On("SetSpendLimit", mock.Anything, "3", "2", tt.limit).Return(true, nil).Once()
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditHouseholdLimit && e.Target == "household:3"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(sharedTypes.SpendLimitRequest{Limit: tt.limit})
+
+			request := httptest.NewRequest(http.MethodPut, "/", body)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uid", "2")
+			ctx := context.WithValue(request.Context(), chi.RouteCtxKey, rctx)
+			request = request.WithContext(context.WithValue(ctx, sharedTypes.UIDKey{}, tt.uid))
+
+			w := httptest.NewRecorder()
+			hn.HandleSetSpendLimit(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleHouseholdWithdraw(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		withdrawn  error
+		statusCode int
+	}{
+		{
+			name:       "Spent from the pool",
+			source:     sharedTypes.BalanceSourceHousehold,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Over the spending limit",
+			source:     sharedTypes.BalanceSourceHousehold,
+			withdrawn:  utils.ErrSpendLimit,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Pool too small",
+			source:     sharedTypes.BalanceSourceHousehold,
+			withdrawn:  utils.ErrPaymentError,
+			statusCode: http.StatusPaymentRequired,
+		},
+		{
+			name:       "Order number already used",
+			source:     sharedTypes.BalanceSourceHousehold,
+			withdrawn:  utils.ErrDuplicate,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Unknown source",
+			source:     "piggy-bank",
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	households := mocks.NewHouseholdStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.UserApp{Households: households, Cfg: cfg, UserLocks: &sync.Map{}, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.source == sharedTypes.BalanceSourceHousehold {
+				households.On("WithdrawHousehold", mock.Anything, "2", "12345678903", float32(150)).
+					Return(sharedTypes.HouseholdBalance{ID: "3", Current: 350}, tt.withdrawn).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditHouseholdWithdraw && e.Target == "household:3"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(sharedTypes.WtihdrawRequest{OrderID: "12345678903", Sum: 150, Source: tt.source})
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			request.Header.Set("Content-Type", "application/json")
+			request = request.WithContext(context.WithValue(request.Context(), sharedTypes.UIDKey{}, "2"))
+
+			w := httptest.NewRecorder()
+			hn.HandleBalanceWithdraw(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
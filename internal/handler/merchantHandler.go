@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type MerchantHandler struct {
+	app    sharedTypes.MerchantApper
+	Cfg    *config.Config
+	logger *zap.SugaredLogger
+}
+
+func InitMerchantHandler(a sharedTypes.MerchantApper, cfg *config.Config, logger *zap.SugaredLogger) *MerchantHandler {
+	return &MerchantHandler{a, cfg, logger}
+}
+
+func (h *MerchantHandler) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	order := sharedTypes.MerchantOrder{}
+	err := json.NewDecoder(r.Body).Decode(&order)
+
+	if err != nil || order.Number == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	order.MerchantID, _ = r.Context().Value(sharedTypes.MerchantKey{}).(string)
+
+	err = h.app.CreateOrder(ctx, order)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrAlreadyCreated):
+			http.Error(w, err.Error(), http.StatusOK)
+			return
+		case errors.Is(err, utils.ErrDuplicate):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrSuspended):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// HandleRefundOrder treats an empty body as a refund of the whole order.
+func (h *MerchantHandler) HandleRefundOrder(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	number := chi.URLParam(r, "number")
+	if _, err := strconv.ParseInt(number, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	req := sharedTypes.RefundRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	merchantID, _ := r.Context().Value(sharedTypes.MerchantKey{}).(string)
+
+	refund, err := h.app.RefundOrder(ctx, merchantID, number, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrPaymentError):
+			http.Error(w, err.Error(), http.StatusPaymentRequired)
+			return
+		case errors.Is(err, utils.ErrNotProcessed):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrRefunded):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrOrderLocked):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(refund)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
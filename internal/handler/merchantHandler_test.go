@@ -0,0 +1,294 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/middleware"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleMerchantCreateOrder(t *testing.T) {
+	key, prefix, _ := utils.GenerateMerchantKey()
+	partnerKey, _, _ := utils.GenerateAPIKey()
+	merchant := sharedTypes.Merchant{ID: "5", Prefix: prefix, Hash: utils.HashAPIKey(key)}
+	basket := []sharedTypes.Good{{Description: "Coffee", Price: 3.5, Quantity: 2}}
+
+	type customer struct {
+		login, cardID string
+		uid           string
+		suspended     bool
+		err           error
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		order      sharedTypes.MerchantOrder
+		statusCode int
+		customer   *customer
+		stored     error
+	}{
+		{
+			name:       "Order created by card",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket}, CardID: "4000123"},
+			statusCode: http.StatusAccepted,
+			customer:   &customer{cardID: "4000123", uid: "1"},
+		},
+		{
+			name:       "Order created by login",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket}, Login: "tester"},
+			statusCode: http.StatusAccepted,
+			customer:   &customer{login: "tester", uid: "1"},
+		},
+		{
+			name:       "Order belongs to another user",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket}, Login: "tester"},
+			statusCode: http.StatusConflict,
+			customer:   &customer{login: "tester", uid: "1"},
+			stored:     utils.ErrDuplicate,
+		},
+		{
+			name:       "Unknown customer",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket}, CardID: "999"},
+			statusCode: http.StatusNotFound,
+			customer:   &customer{cardID: "999", err: utils.ErrNotFound},
+		},
+		{
+			name:       "Suspended customer",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket}, Login: "banned"},
+			statusCode: http.StatusForbidden,
+			customer:   &customer{login: "banned", uid: "2", suspended: true},
+		},
+		{
+			name:       "Both login and card",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket}, Login: "tester", CardID: "4000123"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Empty basket",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903"}, Login: "tester"},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Wrong order number",
+			key:        key,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678901", Goods: basket}, Login: "tester"},
+			statusCode: http.StatusUnprocessableEntity,
+			customer:   &customer{login: "tester", uid: "1"},
+		},
+		{
+			name:       "Partner key is not a merchant key",
+			key:        partnerKey,
+			order:      sharedTypes.MerchantOrder{OrderSubmission: sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket}, Login: "tester"},
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	merchants := mocks.NewMerchantStorager(t)
+	order := mocks.NewOrderStorager(t)
+	accrual := mocks.NewOrderRegisterer(t)
+	audit := mocks.NewAuditStorager(t)
+
+	orderApp := app.OrderApp{Order: order, Cfg: cfg, RegOrder: accrual, Auditor: &app.Auditor{Audit: audit}}
+	a := app.MerchantApp{Merchant: merchants, Orders: &orderApp, Cfg: cfg}
+	hn := handler.InitMerchantHandler(&a, cfg, &zap.SugaredLogger{})
+	guarded := middleware.InitMerchantAuth(merchants)(http.HandlerFunc(hn.HandleCreateOrder))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.key == key {
+				merchants.On("GetMerchant", mock.Anything, prefix).Return(merchant, nil).Once()
+			}
+
+			if tt.customer != nil {
+				merchants.On("FindCustomer", mock.Anything, tt.customer.login, tt.customer.cardID).
+					Return(tt.customer.uid, tt.customer.suspended, tt.customer.err).Once()
+			}
+
+			if tt.customer != nil && tt.customer.err == nil && !tt.customer.suspended && tt.statusCode != http.StatusUnprocessableEntity {
+				accrual.On("RegisterOrder", mock.Anything, tt.order.Number, basket).Return(nil).Once()
+				order.On("CreateDetailedOrder", mock.Anything, tt.customer.uid, merchant.ID, mock.MatchedBy(func(o sharedTypes.OrderSubmission) bool {
+					return o.Number == tt.order.Number && len(o.Goods) == 1
+				})).Return(tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusAccepted {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditOrderUpload && e.Actor == "merchant:5" && e.Target == "order:"+tt.order.Number
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.order)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			request.Header.Set(middleware.MerchantKeyHeader, tt.key)
+
+			w := httptest.NewRecorder()
+			guarded.ServeHTTP(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleRefundOrder(t *testing.T) {
+	processed := sharedTypes.RefundableOrder{UID: "1", Status: sharedTypes.OrderStatusProcessed, Accrual: 50, NetAccrual: 50, Total: 200}
+
+	tests := []struct {
+		name       string
+		body       string
+		policy     string
+		order      sharedTypes.RefundableOrder
+		found      error
+		expected   *sharedTypes.Refund
+		writtenOff float32
+		stored     error
+		statusCode int
+	}{
+		{
+			name:       "Partial refund",
+			body:       `{"amount": 40}`,
+			policy:     sharedTypes.ClawbackPolicyNegative,
+			order:      processed,
+			expected:   &sharedTypes.Refund{Number: "12345678903", UID: "1", Amount: 40, Clawback: 10},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Full refund takes the balance negative",
+			policy:     sharedTypes.ClawbackPolicyNegative,
+			order:      processed,
+			expected:   &sharedTypes.Refund{Number: "12345678903", UID: "1", Amount: 200, Clawback: 50},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Full refund stops at zero",
+			policy:     sharedTypes.ClawbackPolicyFloor,
+			order:      processed,
+			expected:   &sharedTypes.Refund{Number: "12345678903", UID: "1", Amount: 200, Clawback: 50},
+			writtenOff: 20,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Full refund rejected when points are spent",
+			policy:     sharedTypes.ClawbackPolicyReject,
+			order:      processed,
+			expected:   &sharedTypes.Refund{Number: "12345678903", UID: "1", Amount: 200, Clawback: 50},
+			stored:     utils.ErrPaymentError,
+			statusCode: http.StatusPaymentRequired,
+		},
+		{
+			name:       "Refund exceeds the order total",
+			body:       `{"amount": 250}`,
+			policy:     sharedTypes.ClawbackPolicyNegative,
+			order:      processed,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Order still processing",
+			policy:     sharedTypes.ClawbackPolicyNegative,
+			order:      sharedTypes.RefundableOrder{UID: "1", Status: "PROCESSING", Total: 200},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Order already refunded",
+			policy:     sharedTypes.ClawbackPolicyNegative,
+			order:      sharedTypes.RefundableOrder{UID: "1", Status: sharedTypes.OrderStatusProcessed, Accrual: 50, Total: 200, Refunded: 200},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Order changed concurrently",
+			policy:     sharedTypes.ClawbackPolicyNegative,
+			order:      processed,
+			expected:   &sharedTypes.Refund{Number: "12345678903", UID: "1", Amount: 200, Clawback: 50},
+			stored:     utils.ErrOrderLocked,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Order of another merchant",
+			policy:     sharedTypes.ClawbackPolicyNegative,
+			found:      utils.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	merchants := mocks.NewMerchantStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	var userLocks sync.Map
+
+	a := app.MerchantApp{Merchant: merchants, Cfg: cfg, UserLocks: &userLocks, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitMerchantHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.ClawbackPolicy = tt.policy
+
+			lookups := 2
+			if tt.found != nil {
+				lookups = 1
+			}
+
+			merchants.On("GetRefundableOrder", mock.Anything, "5", "12345678903").Return(tt.order, tt.found).Times(lookups)
+
+			if tt.expected != nil {
+				stored := *tt.expected
+				stored.NetAccrual = tt.order.NetAccrual - stored.Clawback
+				stored.WrittenOff = tt.writtenOff
+
+				merchants.On("RefundOrder", mock.Anything, *tt.expected, tt.order, tt.policy).Return(stored, tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditOrderRefund && e.Actor == "merchant:5" && e.Target == "order:12345678903"
+				})).Return(nil).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("number", "12345678903")
+			ctx := context.WithValue(request.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, sharedTypes.MerchantKey{}, "5")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleRefundOrder(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusOK {
+				var refund sharedTypes.Refund
+				json.NewDecoder(w.Body).Decode(&refund)
+
+				assert.Equal(t, tt.order.NetAccrual-tt.expected.Clawback, refund.NetAccrual)
+				assert.Equal(t, tt.writtenOff, refund.WrittenOff)
+			}
+		})
+	}
+}
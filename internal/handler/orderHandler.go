@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/T-V-N/gopherstore/internal/config"
 	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
 	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
@@ -24,9 +28,11 @@ func InitOrderHandler(a sharedTypes.OrderApper, cfg *config.Config, logger *zap.
 	return &OrderHandler{a, cfg, logger}
 }
 
+// HandleCreateOrder accepts either a bare order number as text/plain or an
+// application/json submission carrying the basket.
 func (h *OrderHandler) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
-	cp := r.Header.Get("Content-Type")
-	if cp != "text/plain" {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/plain" && mediaType != "application/json") {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		return
 	}
@@ -34,14 +40,29 @@ func (h *OrderHandler) HandleCreateOrder(w http.ResponseWriter, r *http.Request)
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
 	defer cancel()
 
-	body, err := io.ReadAll(r.Body)
-	if err != nil || len(body) == 0 {
-		http.Error(w, "bad request", http.StatusBadRequest)
-		return
-	}
-
 	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
-	err = h.app.CreateOrder(ctx, string(body), uid)
+
+	if mediaType == "application/json" {
+		order := sharedTypes.OrderSubmission{}
+		err = json.NewDecoder(r.Body).Decode(&order)
+
+		if err != nil || order.Number == "" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		err = h.app.SubmitOrder(ctx, uid, order)
+	} else {
+		var body []byte
+
+		body, err = io.ReadAll(r.Body)
+		if err != nil || len(body) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		err = h.app.CreateOrder(ctx, string(body), uid)
+	}
 
 	if err != nil {
 		switch {
@@ -63,6 +84,87 @@ func (h *OrderHandler) HandleCreateOrder(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusAccepted)
 }
 
+// HandleCreateOrders takes a JSON array or newline-delimited numbers and
+// reports the outcome for each one.
+func (h *OrderHandler) HandleCreateOrders(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/plain" && mediaType != "application/json") {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	numbers := []string{}
+
+	if mediaType == "application/json" {
+		err = json.NewDecoder(r.Body).Decode(&numbers)
+	} else {
+		var body []byte
+
+		body, err = io.ReadAll(r.Body)
+
+		for _, line := range strings.Split(string(body), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				numbers = append(numbers, line)
+			}
+		}
+	}
+
+	if err != nil || len(numbers) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	if len(numbers) > h.Cfg.OrderBatchLimit {
+		http.Error(w, utils.ErrWrongFormat.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	// each number gets its own timeout inside the app
+	results := h.app.CreateOrders(r.Context(), uid, numbers)
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMultiStatus)
+
+	err = json.NewEncoder(w).Encode(results)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *OrderHandler) HandleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	number := chi.URLParam(r, "number")
+	if _, err := strconv.ParseInt(number, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	err := h.app.CancelOrder(ctx, uid, number)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrOrderLocked):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
 func (h *OrderHandler) HandleListOrder(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
 	defer cancel()
@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/T-V-N/gopherstore/internal/app"
 	"github.com/T-V-N/gopherstore/internal/handler"
 	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
 	"github.com/T-V-N/gopherstore/mocks"
@@ -212,3 +214,266 @@ func Test_HandleListOrder(t *testing.T) {
 		})
 	}
 }
+
+func Test_HandleSubmitOrder(t *testing.T) {
+	purchased := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	future := time.Now().Add(time.Hour)
+	basket := []sharedTypes.Good{{Description: "Chair", Price: 120, Quantity: 1}}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		statusCode  int
+		submission  *sharedTypes.OrderSubmission
+		number      string
+	}{
+		{
+			name:        "JSON submission with basket",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"order":"12345678903","goods":[{"description":"Chair","price":120}],"total":120,"purchased_at":"` + purchased.Format(time.RFC3339) + `"}`,
+			statusCode:  http.StatusAccepted,
+			submission:  &sharedTypes.OrderSubmission{Number: "12345678903", Goods: basket, Total: 120, PurchasedAt: &purchased},
+		},
+		{
+			name:        "Plain number with charset",
+			contentType: "text/plain; charset=utf-8",
+			body:        "12345678903",
+			statusCode:  http.StatusAccepted,
+			number:      "12345678903",
+		},
+		{
+			name:        "Purchase in the future",
+			contentType: "application/json",
+			body:        `{"order":"12345678903","purchased_at":"` + future.Format(time.RFC3339) + `"}`,
+			statusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Negative price",
+			contentType: "application/json",
+			body:        `{"order":"12345678903","goods":[{"description":"Chair","price":-1}]}`,
+			statusCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Missing order number",
+			contentType: "application/json",
+			body:        `{"goods":[]}`,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "Unsupported content type",
+			contentType: "application/xml",
+			body:        "<order/>",
+			statusCode:  http.StatusBadRequest,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	order := mocks.NewOrderStorager(t)
+	accrual := mocks.NewOrderRegisterer(t)
+
+	a := app.OrderApp{Order: order, Cfg: cfg, RegOrder: accrual}
+	hn := handler.InitOrderHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.submission != nil {
+				accrual.On("RegisterOrder", mock.Anything, tt.submission.Number, tt.submission.Goods).Return(nil).Once()
+				order.On("CreateDetailedOrder", mock.Anything, "1337", "", mock.MatchedBy(func(o sharedTypes.OrderSubmission) bool {
+					return o.Number == tt.submission.Number && o.Total == tt.submission.Total && o.PurchasedAt.Equal(*tt.submission.PurchasedAt) &&
+						o.ExpectedAccrual == tt.submission.Total*cfg.PendingAccrualRate/100
+				})).Return(nil).Once()
+			}
+
+			if tt.number != "" {
+				accrual.On("RegisterOrder", mock.Anything, tt.number, []sharedTypes.Good(nil)).Return(nil).Once()
+				order.On("CreateOrder", mock.Anything, tt.number, "1337").Return(nil).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			request.Header.Set("Content-Type", tt.contentType)
+
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1337")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleCreateOrder(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleCreateOrders(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		statusCode  int
+		results     []sharedTypes.OrderResult
+	}{
+		{
+			name:        "Newline-delimited batch",
+			contentType: "text/plain",
+			body:        "12345678903\n\n 4561261212345467 \r\n12345678901\n49927398716\n",
+			statusCode:  http.StatusMultiStatus,
+			results: []sharedTypes.OrderResult{
+				{Number: "12345678903", Status: app.OrderResultAccepted},
+				{Number: "4561261212345467", Status: app.OrderResultUploaded},
+				{Number: "12345678901", Status: app.OrderResultInvalid},
+				{Number: "49927398716", Status: app.OrderResultConflict},
+			},
+		},
+		{
+			name:        "JSON batch",
+			contentType: "application/json; charset=utf-8",
+			body:        `["12345678903"]`,
+			statusCode:  http.StatusMultiStatus,
+			results:     []sharedTypes.OrderResult{{Number: "12345678903", Status: app.OrderResultAccepted}},
+		},
+		{
+			name:        "Empty batch",
+			contentType: "application/json",
+			body:        `[]`,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "Batch over the limit",
+			contentType: "text/plain",
+			body:        strings.Repeat("12345678903\n", 101),
+			statusCode:  http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	order := mocks.NewOrderStorager(t)
+	accrual := mocks.NewOrderRegisterer(t)
+
+	a := app.OrderApp{Order: order, Cfg: cfg, RegOrder: accrual}
+	hn := handler.InitOrderHandler(&a, cfg, &zap.SugaredLogger{})
+
+	storeResults := map[string]error{
+		app.OrderResultAccepted: nil,
+		app.OrderResultUploaded: utils.ErrAlreadyCreated,
+		app.OrderResultConflict: utils.ErrDuplicate,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, result := range tt.results {
+				if result.Status == app.OrderResultInvalid {
+					continue
+				}
+
+				accrual.On("RegisterOrder", mock.Anything, result.Number, []sharedTypes.Good(nil)).Return(nil).Once()
+				order.On("CreateOrder", mock.Anything, result.Number, "1337").Return(storeResults[result.Status]).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.body))
+			request.Header.Set("Content-Type", tt.contentType)
+
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1337")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleCreateOrders(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.results != nil {
+				var results []sharedTypes.OrderResult
+				json.NewDecoder(w.Body).Decode(&results)
+
+				assert.Equal(t, tt.results, results)
+			}
+		})
+	}
+}
+
+func Test_HandleCreateOrdersRequestGone(t *testing.T) {
+	cfg, _ := InitTestConfig()
+
+	a := app.OrderApp{Order: mocks.NewOrderStorager(t), Cfg: cfg, RegOrder: mocks.NewOrderRegisterer(t)}
+	hn := handler.InitOrderHandler(&a, cfg, &zap.SugaredLogger{})
+
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("12345678903\n4561261212345467\n"))
+	request.Header.Set("Content-Type", "text/plain")
+
+	ctx, cancel := context.WithCancel(context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1337"))
+	cancel()
+
+	w := httptest.NewRecorder()
+	hn.HandleCreateOrders(w, request.WithContext(ctx))
+
+	results := []sharedTypes.OrderResult{}
+	json.NewDecoder(w.Body).Decode(&results)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Equal(t, []sharedTypes.OrderResult{
+		{Number: "12345678903", Status: app.OrderResultSkipped},
+		{Number: "4561261212345467", Status: app.OrderResultSkipped},
+	}, results)
+}
+
+func Test_HandleCancelOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		number     string
+		stored     error
+		statusCode int
+	}{
+		{
+			name:       "New order cancelled",
+			number:     "12345678903",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Order already processing",
+			number:     "4561261212345467",
+			stored:     utils.ErrOrderLocked,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Order of another user",
+			number:     "49927398716",
+			stored:     utils.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Malformed number",
+			number:     "abc",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	order := mocks.NewOrderStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.OrderApp{Order: order, Cfg: cfg, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitOrderHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode != http.StatusBadRequest {
+				order.On("CancelOrder", mock.Anything, "1337", tt.number).Return(tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditOrderCancel && e.Target == "order:"+tt.number
+				})).Return(nil).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("number", tt.number)
+			ctx := context.WithValue(request.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, sharedTypes.UIDKey{}, "1337")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleCancelOrder(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/middleware"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_PartnerGetBalance(t *testing.T) {
+	key, prefix, _ := utils.GenerateAPIKey()
+	stored := sharedTypes.APIKey{
+		ID:        "7",
+		Prefix:    prefix,
+		Hash:      utils.HashAPIKey(key),
+		Scopes:    []string{sharedTypes.ScopeBalanceRead},
+		RateLimit: 2,
+	}
+	writeOnly := stored
+	writeOnly.Scopes = []string{sharedTypes.ScopeOrdersWrite}
+
+	type mockSettings struct {
+		apiKey    *sharedTypes.APIKey
+		hits      int
+		user      *sharedTypes.User
+		userError error
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		uid        string
+		statusCode int
+		retryAfter bool
+		mockData   mockSettings
+	}{
+		{
+			name:       "Balance returned",
+			key:        key,
+			uid:        "1",
+			statusCode: http.StatusOK,
+			mockData:   mockSettings{apiKey: &stored, hits: 1, user: &sharedTypes.User{UID: "1", CurrentBalance: 500, Withdrawn: 42}},
+		},
+		{
+			name:       "Missing key",
+			uid:        "1",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Malformed key",
+			key:        "not-a-key",
+			uid:        "1",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Wrong secret",
+			key:        key + "x",
+			uid:        "1",
+			statusCode: http.StatusUnauthorized,
+			mockData:   mockSettings{apiKey: &stored},
+		},
+		{
+			name:       "Missing scope",
+			key:        key,
+			uid:        "1",
+			statusCode: http.StatusForbidden,
+			mockData:   mockSettings{apiKey: &writeOnly, hits: 1},
+		},
+		{
+			name:       "Rate limited",
+			key:        key,
+			uid:        "1",
+			statusCode: http.StatusTooManyRequests,
+			retryAfter: true,
+			mockData:   mockSettings{apiKey: &stored, hits: 3},
+		},
+		{
+			name:       "Unknown user",
+			key:        key,
+			uid:        "2",
+			statusCode: http.StatusNotFound,
+			mockData:   mockSettings{apiKey: &stored, hits: 1, user: &sharedTypes.User{}, userError: pgx.ErrNoRows},
+		},
+		{
+			name:       "Suspended user",
+			key:        key,
+			uid:        "3",
+			statusCode: http.StatusForbidden,
+			mockData:   mockSettings{apiKey: &stored, hits: 1, user: &sharedTypes.User{UID: "3", Suspended: true}},
+		},
+	}
+	cfg, _ := InitTestConfig()
+	keys := mocks.NewAPIKeyStorager(t)
+	user := mocks.NewUserStorage(t)
+
+	a := app.PartnerApp{User: user, Cfg: cfg}
+	hn := handler.InitPartnerHandler(&a, cfg, &zap.SugaredLogger{})
+
+	r := chi.NewRouter()
+	r.Route("/api/partner/users/{uid}", func(partnerRouter chi.Router) {
+		partnerRouter.Use(middleware.InitAPIKeyAuth(cfg, keys))
+		partnerRouter.With(middleware.RequireScope(sharedTypes.ScopeBalanceRead)).Get("/balance", hn.HandleGetBalance)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockData.apiKey != nil {
+				keys.On("GetAPIKey", mock.Anything, prefix).Return(*tt.mockData.apiKey, nil).Once()
+			}
+
+			if tt.mockData.hits > 0 {
+				keys.On("HitRateLimit", mock.Anything, "7", cfg.PartnerRateWindow).Return(tt.mockData.hits, 30, nil).Once()
+			}
+
+			if tt.mockData.user != nil {
+				user.On("GetUserByID", mock.Anything, tt.uid).Return(*tt.mockData.user, tt.mockData.userError).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/api/partner/users/"+tt.uid+"/balance", nil)
+			if tt.key != "" {
+				request.Header.Set(middleware.APIKeyHeader, tt.key)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After") != "")
+
+			if tt.statusCode == http.StatusOK {
+				var balance sharedTypes.Balance
+				json.NewDecoder(w.Body).Decode(&balance)
+
+				assert.Equal(t, sharedTypes.Balance{Current: 500, Withdrawn: 42}, balance)
+			}
+		})
+	}
+}
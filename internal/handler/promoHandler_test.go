@@ -0,0 +1,185 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleRedeemPromo(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		redeemed   error
+		statusCode int
+	}{
+		{
+			name:       "Code redeemed",
+			code:       "spring-bonus-24",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Unknown code",
+			code:       "NOSUCHCODE",
+			redeemed:   utils.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Code used up",
+			code:       "LIMITED100",
+			redeemed:   utils.ErrPromoExhausted,
+			statusCode: http.StatusGone,
+		},
+		{
+			name:       "Already redeemed by the user",
+			code:       "ONEPERUSER",
+			redeemed:   utils.ErrPromoLimit,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Empty code",
+			code:       " - ",
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	promo := mocks.NewPromoStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.PromoApp{Promo: promo, Cfg: cfg, UserLocks: &sync.Map{}, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitPromoHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode != http.StatusUnprocessableEntity {
+				promo.On("RedeemPromoCode", mock.Anything, "7", utils.HashPromoCode(tt.code, cfg.SecretKey)).
+					Return(sharedTypes.PromoRedemption{ID: "11", CodeID: "5", Amount: 250}, tt.redeemed).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditPromoRedeem && e.Target == "promo:5"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(sharedTypes.PromoRedeemRequest{Code: tt.code})
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "7")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleRedeemPromo(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusOK {
+				got := sharedTypes.PromoRedemption{}
+				json.NewDecoder(w.Body).Decode(&got)
+
+				assert.Equal(t, float32(250), got.Amount)
+			}
+		})
+	}
+}
+
+func Test_HandleCreatePromoCode(t *testing.T) {
+	expires := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		request    sharedTypes.PromoCode
+		stored     error
+		statusCode int
+	}{
+		{
+			name:       "Generated code",
+			request:    sharedTypes.PromoCode{Amount: 100, MaxRedemptions: 500, ExpiresAt: expires},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Chosen code already exists",
+			request:    sharedTypes.PromoCode{Code: "WELCOME2024", Amount: 100, ExpiresAt: expires},
+			stored:     utils.ErrDuplicate,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Chosen code too short",
+			request:    sharedTypes.PromoCode{Code: "HI", Amount: 100, ExpiresAt: expires},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Already expired",
+			request:    sharedTypes.PromoCode{Amount: 100, ExpiresAt: time.Now().Add(-time.Hour)},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "No amount",
+			request:    sharedTypes.PromoCode{ExpiresAt: expires},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	promo := mocks.NewPromoStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.PromoApp{Promo: promo, Cfg: cfg, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitPromoHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.statusCode == http.StatusCreated || tt.stored != nil {
+				promo.On("CreatePromoCode", mock.Anything, mock.MatchedBy(func(p sharedTypes.PromoCode) bool {
+					return p.Hash == utils.HashPromoCode(p.Code, cfg.SecretKey) && p.Hint == utils.PromoCodeHint(p.Code) && p.PerUserLimit == 1 && p.CreatedBy == "42"
+				})).Return(func(_ context.Context, p sharedTypes.PromoCode) sharedTypes.PromoCode {
+					p.ID = "5"
+					return p
+				}, tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusCreated {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditAdminPromoCreate && e.Target == "promo:5"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "42")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleCreatePromoCode(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusCreated {
+				got := sharedTypes.PromoCode{}
+				json.NewDecoder(w.Body).Decode(&got)
+
+				assert.Len(t, utils.NormalizeCode(got.Code), 12)
+				assert.Equal(t, utils.PromoCodeHint(got.Code), got.Hint)
+			}
+		})
+	}
+}
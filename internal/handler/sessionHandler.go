@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SessionHandler struct {
+	app    sharedTypes.SessionApper
+	Cfg    *config.Config
+	logger *zap.SugaredLogger
+}
+
+func InitSessionHandler(a sharedTypes.SessionApper, cfg *config.Config, logger *zap.SugaredLogger) *SessionHandler {
+	return &SessionHandler{a, cfg, logger}
+}
+
+func (h *SessionHandler) HandleListSessions(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+	sid, _ := r.Context().Value(sharedTypes.SIDKey{}).(string)
+
+	list, err := h.app.ListSessions(ctx, uid, sid)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *SessionHandler) HandleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	sid := chi.URLParam(r, "id")
+	if _, err := strconv.ParseInt(sid, 10, 64); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.RevokeSession(ctx, uid, sid)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
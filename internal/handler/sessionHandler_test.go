@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleListSessions(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	session := mocks.NewSessionStorager(t)
+
+	a := app.SessionApp{Session: session, Cfg: cfg}
+	hn := handler.InitSessionHandler(&a, cfg, &zap.SugaredLogger{})
+
+	mockTime := time.Now()
+	session.On("ListSessions", mock.Anything, "1337").Return([]sharedTypes.Session{
+		{ID: "1", IP: "10.0.0.1", UserAgent: "curl", CreatedAt: mockTime, LastSeenAt: mockTime},
+		{ID: "2", IP: "10.0.0.2", UserAgent: "firefox", CreatedAt: mockTime, LastSeenAt: mockTime},
+	}, nil).Once()
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1337")
+	ctx = context.WithValue(ctx, sharedTypes.SIDKey{}, "2")
+	request = request.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	hn.HandleListSessions(w, request)
+
+	var l []sharedTypes.Session
+	json.NewDecoder(w.Body).Decode(&l)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, len(l))
+	assert.False(t, l[0].Current)
+	assert.True(t, l[1].Current)
+}
+
+func Test_HandleRevokeSession(t *testing.T) {
+	type mockSettings struct {
+		isNeeded bool
+		result   []interface{}
+	}
+
+	tests := []struct {
+		name       string
+		sid        string
+		statusCode int
+		mockData   mockSettings
+	}{
+		{
+			name:       "Session revoked",
+			sid:        "5",
+			statusCode: http.StatusOK,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{true, nil}},
+		},
+		{
+			name:       "Foreign or unknown session",
+			sid:        "6",
+			statusCode: http.StatusNotFound,
+			mockData:   mockSettings{isNeeded: true, result: []interface{}{false, nil}},
+		},
+		{
+			name:       "Malformed id",
+			sid:        "abc",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	session := mocks.NewSessionStorager(t)
+
+	a := app.SessionApp{Session: session, Cfg: cfg}
+	hn := handler.InitSessionHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockData.isNeeded {
+				session.On("RevokeSession", mock.Anything, "1337", tt.sid).Return(tt.mockData.result...).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.sid)
+
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1337")
+			ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleRevokeSession(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
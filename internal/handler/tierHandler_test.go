@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleGetTier(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tiers := []sharedTypes.Tier{
+		{Name: "standard", MinAccrual: 0, Multiplier: 1},
+		{Name: "silver", MinAccrual: 1000, Multiplier: 1.1},
+		{Name: "gold", MinAccrual: 5000, Multiplier: 1.25},
+	}
+
+	tests := []struct {
+		name       string
+		uid        string
+		member     sharedTypes.TierMember
+		found      error
+		statusCode int
+		want       sharedTypes.TierStatus
+	}{
+		{
+			name:       "Progress towards the next tier",
+			uid:        "1",
+			member:     sharedTypes.TierMember{UID: "1", Tier: "silver", Since: since, RollingAccrual: 4200},
+			statusCode: http.StatusOK,
+			want:       sharedTypes.TierStatus{Since: since, Tier: "silver", Multiplier: 1.1, RollingAccrual: 4200, NextTier: "gold", NextTierAccrual: 5000, Remaining: 800},
+		},
+		{
+			name:       "Top tier",
+			uid:        "2",
+			member:     sharedTypes.TierMember{UID: "2", Tier: "gold", Since: since, RollingAccrual: 9000},
+			statusCode: http.StatusOK,
+			want:       sharedTypes.TierStatus{Since: since, Tier: "gold", Multiplier: 1.25, RollingAccrual: 9000},
+		},
+		{
+			name:       "Rolling accrual below the current tier",
+			uid:        "3",
+			member:     sharedTypes.TierMember{UID: "3", Tier: "silver", Since: since, RollingAccrual: 300},
+			statusCode: http.StatusOK,
+			want:       sharedTypes.TierStatus{Since: since, Tier: "silver", Multiplier: 1.1, RollingAccrual: 300, NextTier: "gold", NextTierAccrual: 5000, Remaining: 4700},
+		},
+		{
+			name:       "Deleted user",
+			uid:        "4",
+			found:      utils.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	tier := mocks.NewTierStorager(t)
+
+	a := app.TierApp{Tier: tier, Cfg: cfg}
+	hn := handler.InitTierHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier.On("ListTiers", mock.Anything).Return(tiers, nil).Once()
+			tier.On("GetTierMember", mock.Anything, tt.uid).Return(tt.member, tt.found).Once()
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, tt.uid)
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleGetTier(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusOK {
+				var status sharedTypes.TierStatus
+				json.NewDecoder(w.Body).Decode(&status)
+
+				assert.Equal(t, tt.want, status)
+			}
+		})
+	}
+}
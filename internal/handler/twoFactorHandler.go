@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+func (h *UserHandler) HandleLoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	cp := r.Header.Get("Content-Type")
+	if cp != "application/json" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	req := sharedTypes.TwoFactorChallenge{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil || req.Challenge == "" || req.Code == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.app.LoginTwoFactor(ctx, req.Challenge, req.Code)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotAuthorized):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		case errors.Is(err, utils.ErrSuspended):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, utils.ErrTooManyTries):
+			setRetryAfter(w, err)
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Authorization", fmt.Sprintf("Bearer %v", token))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *UserHandler) HandleEnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	enrollment, err := h.app.EnrollTOTP(ctx, uid)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTwoFactorOn):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(enrollment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.TwoFactorCode{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil || req.Code == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.app.ConfirmTOTP(ctx, uid, req.Code)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTwoFactorOn), errors.Is(err, utils.ErrTwoFactorOff):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrInvalidCode):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(sharedTypes.RecoveryCodes{Codes: codes})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleDisableTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.TwoFactorCode{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil || req.Code == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	err = h.app.DisableTOTP(ctx, uid, req.Code)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTwoFactorOff):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrInvalidCode):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
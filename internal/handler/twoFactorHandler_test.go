@@ -0,0 +1,199 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/app"
+	"github.com/T-V-N/gopherstore/internal/auth"
+	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"go.uber.org/zap"
+
+	"github.com/T-V-N/gopherstore/mocks"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleLoginTwoFactor(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	user := mocks.NewUserStorage(t)
+	loginEvents := mocks.NewLoginEventStorager(t)
+	session := mocks.NewSessionStorager(t)
+	hasher, _ := utils.InitPasswordHasher(cfg)
+	policy, _ := utils.InitPasswordPolicy(cfg)
+
+	a := app.UserApp{User: user, LoginEvents: loginEvents, Session: session, Cfg: cfg, Hasher: hasher, Policy: policy}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	secret, _ := utils.GenerateTOTPSecret()
+	hash, _ := hasher.Hash(context.Background(), "password")
+	twoFactorUser := sharedTypes.User{UID: "7", Login: "tester", PasswordHash: hash, TOTPSecret: secret, TOTPEnabled: true}
+
+	user.On("GetUser", mock.Anything, mock.Anything).Return(twoFactorUser, nil)
+	user.On("GetUserByID", mock.Anything, "7").Return(twoFactorUser, nil)
+	user.On("UseTOTPStep", mock.Anything, "7", mock.Anything).Return(true, nil).Once()
+	user.On("UseTOTPStep", mock.Anything, "7", mock.Anything).Return(false, nil)
+	user.On("UseRecoveryCode", mock.Anything, "7", mock.Anything).Return(false, nil)
+	loginEvents.On("StartLoginAttempt", mock.Anything, mock.Anything, mock.Anything).Return(startedAttempt, sharedTypes.LoginFailures{}, nil)
+	loginEvents.On("FinishLoginAttempt", mock.Anything, mock.Anything).Return(nil)
+	session.On("CreateSession", mock.Anything, "7", mock.Anything, mock.Anything).Return("1", nil).Once()
+
+	body := bytes.NewBuffer([]byte{})
+	json.NewEncoder(body).Encode(sharedTypes.Credentials{Login: "tester", Password: "password"})
+
+	request := httptest.NewRequest(http.MethodPost, "/", body)
+	request.Header.Add("Content-type", "application/json")
+
+	w := httptest.NewRecorder()
+	hn.HandleLogin(w, request)
+
+	res := w.Result()
+	res.Body.Close()
+
+	var challenge sharedTypes.TwoFactorChallenge
+	json.NewDecoder(w.Body).Decode(&challenge)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Empty(t, res.Header.Get("Authorization"))
+	assert.NotEmpty(t, challenge.Challenge)
+
+	_, err := auth.ParseToken(challenge.Challenge, []byte(cfg.SecretKey))
+	assert.Error(t, err)
+
+	code, _ := utils.TOTPCode(secret, time.Now())
+
+	tests := []struct {
+		name       string
+		challenge  string
+		code       string
+		statusCode int
+		auth       string
+	}{
+		{
+			name:       "Wrong code",
+			challenge:  challenge.Challenge,
+			code:       "000000x",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Invalid challenge",
+			challenge:  "not a token",
+			code:       code,
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Valid code",
+			challenge:  challenge.Challenge,
+			code:       code,
+			statusCode: http.StatusOK,
+			auth:       "Bearer",
+		},
+		{
+			name:       "Replayed code",
+			challenge:  challenge.Challenge,
+			code:       code,
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(sharedTypes.TwoFactorChallenge{Challenge: tt.challenge, Code: tt.code})
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			request.Header.Add("Content-type", "application/json")
+
+			w := httptest.NewRecorder()
+			hn.HandleLoginTwoFactor(w, request)
+
+			res := w.Result()
+			res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Contains(t, res.Header.Get("Authorization"), tt.auth)
+		})
+	}
+}
+
+func Test_HandleConfirmTOTP(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	user := mocks.NewUserStorage(t)
+
+	a := app.UserApp{User: user, Cfg: cfg}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.TOTPCode(secret, time.Now())
+
+	tests := []struct {
+		name       string
+		code       string
+		user       sharedTypes.User
+		enable     bool
+		statusCode int
+		codes      int
+	}{
+		{
+			name:       "Not enrolled",
+			code:       code,
+			user:       sharedTypes.User{UID: "1", Login: "tester"},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Already enabled",
+			code:       code,
+			user:       sharedTypes.User{UID: "1", Login: "tester", TOTPSecret: secret, TOTPEnabled: true},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Wrong code",
+			code:       "12345",
+			user:       sharedTypes.User{UID: "1", Login: "tester", TOTPSecret: secret},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Valid code returns recovery codes",
+			code:       code,
+			user:       sharedTypes.User{UID: "1", Login: "tester", TOTPSecret: secret},
+			enable:     true,
+			statusCode: http.StatusOK,
+			codes:      cfg.RecoveryCodeCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user.On("GetUserByID", mock.Anything, "1").Return(tt.user, nil).Once()
+
+			if tt.enable {
+				user.On("EnableTOTP", mock.Anything, "1", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(sharedTypes.TwoFactorCode{Code: tt.code})
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleConfirmTOTP(w, request)
+
+			var codes sharedTypes.RecoveryCodes
+			json.NewDecoder(w.Body).Decode(&codes)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, tt.codes, len(codes.Codes))
+		})
+	}
+}
@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
-	"github.com/T-V-N/gopherstore/internal/auth"
 	"github.com/T-V-N/gopherstore/internal/config"
 	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
 	"github.com/T-V-N/gopherstore/internal/utils"
@@ -43,7 +44,7 @@ func (h *UserHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	uid, err := h.app.Register(ctx, cred)
+	token, err := h.app.Register(ctx, cred)
 
 	if err != nil {
 		switch {
@@ -53,19 +54,21 @@ func (h *UserHandler) HandleRegister(w http.ResponseWriter, r *http.Request) {
 		case errors.Is(err, utils.ErrDuplicate):
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
+		case errors.Is(err, utils.ErrReferralCode):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrReferralLimit):
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		case errors.Is(err, utils.ErrBusy):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	}
 
-	token, err := auth.CreateToken(uid, h.Cfg)
-
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
 	w.Header().Add("Authorization", fmt.Sprintf("Bearer %v", token))
 	w.WriteHeader(http.StatusOK)
 }
@@ -89,7 +92,7 @@ func (h *UserHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.app.Login(ctx, cred)
+	result, err := h.app.Login(ctx, cred)
 
 	if err != nil {
 		switch {
@@ -99,13 +102,46 @@ func (h *UserHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		case errors.Is(err, utils.ErrNotAuthorized):
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
+		case errors.Is(err, utils.ErrSuspended):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, utils.ErrTooManyTries):
+			setRetryAfter(w, err)
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+
+			return
+		case errors.Is(err, utils.ErrBusy):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 	}
 
-	w.Header().Add("Authorization", fmt.Sprintf("Bearer %v", token))
+	if result.Challenge != "" {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+
+		err = json.NewEncoder(w).Encode(sharedTypes.TwoFactorChallenge{Challenge: result.Challenge})
+		if err != nil {
+			h.logger.Errorw("Unable to encode challenge", "err", err)
+		}
+
+		return
+	}
+
+	w.Header().Add("Authorization", fmt.Sprintf("Bearer %v", result.Token))
 	w.WriteHeader(http.StatusOK)
 }
 
+func setRetryAfter(w http.ResponseWriter, err error) {
+	var retryErr utils.RetryAfterError
+	if errors.As(err, &retryErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+	}
+}
+
 func (h *UserHandler) HandleGetBalance(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
 	defer cancel()
@@ -122,6 +158,183 @@ func (h *UserHandler) HandleGetBalance(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *UserHandler) HandleListPendingAccruals(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	list, err := h.app.ListPendingAccruals(ctx, uid)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNoData):
+			http.Error(w, err.Error(), http.StatusNoContent)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleListExpiringPoints(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	expiring, err := h.app.ListExpiringPoints(ctx, uid)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(expiring)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleListAdjustments(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	list, err := h.app.ListAdjustments(ctx, uid)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNoData):
+			http.Error(w, err.Error(), http.StatusNoContent)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleBalanceTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	req := sharedTypes.TransferRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.app.TransferBalance(ctx, uid, req)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWrongFormat):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, utils.ErrFrozen):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, utils.ErrPaymentError):
+			http.Error(w, err.Error(), http.StatusPaymentRequired)
+			return
+		case errors.Is(err, utils.ErrTransferLimit):
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(transfer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleListTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	list, err := h.app.ListTransfers(ctx, uid)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNoData):
+			http.Error(w, err.Error(), http.StatusNoContent)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *UserHandler) HandleListReferrals(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
+	defer cancel()
+
+	uid, _ := r.Context().Value(sharedTypes.UIDKey{}).(string)
+
+	summary, err := h.app.ListReferrals(ctx, uid)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(summary)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *UserHandler) HandleBalanceWithdraw(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.Cfg.ContextCancelTimeout)*time.Second)
 	defer cancel()
@@ -136,13 +349,25 @@ func (h *UserHandler) HandleBalanceWithdraw(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = h.app.WithdrawBalance(ctx, uid, withdrawRequest.OrderID, withdrawRequest.Sum)
+	err = h.app.WithdrawBalance(ctx, uid, withdrawRequest.OrderID, withdrawRequest.Sum, withdrawRequest.Source)
 
 	if err != nil {
 		switch {
+		case errors.Is(err, utils.ErrFrozen):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
 		case errors.Is(err, utils.ErrPaymentError):
 			http.Error(w, err.Error(), http.StatusPaymentRequired)
 			return
+		case errors.Is(err, utils.ErrSpendLimit):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, utils.ErrDuplicate):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, utils.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
 		case errors.Is(err, utils.ErrWrongFormat):
 			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 			return
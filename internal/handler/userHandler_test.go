@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/T-V-N/gopherstore/internal/app"
 	"github.com/T-V-N/gopherstore/internal/handler"
@@ -73,15 +76,35 @@ func Test_HandlerRegister(t *testing.T) {
 				statusCode: http.StatusBadRequest,
 			},
 		},
+		{
+			name:        "Short password is rejected",
+			contentType: "application/json",
+			body:        sharedTypes.Credentials{Login: "tester", Password: "pass"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:        "Password equal to login is rejected",
+			contentType: "application/json",
+			body:        sharedTypes.Credentials{Login: "longtester", Password: "LongTester"},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
 	}
 	cfg, _ := InitTestConfig()
 	user := mocks.NewUserStorage(t)
 	withdrawal := mocks.NewWithdrawalStorage(t)
+	session := mocks.NewSessionStorager(t)
+	hasher, _ := utils.InitPasswordHasher(cfg)
+	policy, _ := utils.InitPasswordPolicy(cfg)
 
-	a := app.UserApp{User: user, Withdrawal: withdrawal, Cfg: cfg}
+	a := app.UserApp{User: user, Withdrawal: withdrawal, Session: session, Cfg: cfg, Hasher: hasher, Policy: policy}
 	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
 
 	user.On("CreateUser", mock.Anything, mock.Anything).Return("some_uid", nil).Once()
+	session.On("CreateSession", mock.Anything, "some_uid", mock.Anything, mock.Anything).Return("1", nil).Once()
 	user.On("CreateUser", mock.Anything, mock.Anything).Return("", utils.ErrDuplicate)
 
 	for _, tt := range tests {
@@ -108,6 +131,7 @@ func Test_HandlerLogin(t *testing.T) {
 		response   string
 		statusCode int
 		auth       string
+		retryAfter bool
 	}
 
 	tests := []struct {
@@ -152,16 +176,47 @@ func Test_HandlerLogin(t *testing.T) {
 				auth:       "",
 			},
 		},
+		{
+			name:        "Locked out login",
+			contentType: "application/json",
+			body:        sharedTypes.Credentials{Login: "victim", Password: "password"},
+			want: want{
+				statusCode: http.StatusTooManyRequests,
+				auth:       "",
+				retryAfter: true,
+			},
+		},
 	}
 	cfg, _ := InitTestConfig()
 	user := mocks.NewUserStorage(t)
 	withdrawal := mocks.NewWithdrawalStorage(t)
+	loginEvents := mocks.NewLoginEventStorager(t)
+	session := mocks.NewSessionStorager(t)
+	hasher, _ := utils.InitPasswordHasher(cfg)
+	policy, _ := utils.InitPasswordPolicy(cfg)
 
-	a := app.UserApp{User: user, Withdrawal: withdrawal, Cfg: cfg}
+	a := app.UserApp{User: user, Withdrawal: withdrawal, LoginEvents: loginEvents, Session: session, Cfg: cfg, Hasher: hasher, Policy: policy}
 	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
 
+	session.On("CreateSession", mock.Anything, "1", mock.Anything, mock.Anything).Return("1", nil).Once()
+
+	loginEvents.On("StartLoginAttempt", mock.Anything, loginAttempt("victim"), mock.Anything).Return(startedAttempt, sharedTypes.LoginFailures{LoginCount: cfg.LoginMaxFailures, LoginLast: time.Now()}, nil).Once()
+	loginEvents.On("StartLoginAttempt", mock.Anything, mock.Anything, mock.Anything).Return(startedAttempt, sharedTypes.LoginFailures{}, nil)
+	loginEvents.On("FinishLoginAttempt", mock.Anything, mock.MatchedBy(func(e sharedTypes.LoginEvent) bool {
+		return e.ID == "1" && e.Outcome == app.LoginOutcomeSuccess && e.UID == "1"
+	})).Return(nil).Once()
+	loginEvents.On("FinishLoginAttempt", mock.Anything, mock.MatchedBy(func(e sharedTypes.LoginEvent) bool {
+		return e.ID == "1" && e.Outcome == app.LoginOutcomeFailure && e.Login == "faker"
+	})).Return(nil).Once()
+	loginEvents.On("FinishLoginAttempt", mock.Anything, mock.MatchedBy(func(e sharedTypes.LoginEvent) bool {
+		return e.ID == "1" && e.Outcome == app.LoginOutcomeBlocked && e.Login == "victim"
+	})).Return(nil).Once()
+
 	user.On("GetUser", mock.Anything, mock.Anything).Return(sharedTypes.User{UID: "1", Login: "tester", PasswordHash: "$2a$14$Shj508U123/afnKaPZV4BOTlR3Dt89EGONrff25rbZsg49vzdo8Ga", CurrentBalance: 0, Withdrawn: 0, CreatedAt: "-"}, nil).Once()
 	user.On("GetUser", mock.Anything, mock.Anything).Return(sharedTypes.User{}, utils.ErrNotAuthorized)
+	user.On("UpdatePasswordHash", mock.Anything, "1", mock.MatchedBy(func(hash string) bool {
+		return strings.HasPrefix(hash, "$argon2id$")
+	})).Return(nil).Once()
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -185,19 +240,57 @@ func Test_HandlerLogin(t *testing.T) {
 
 			assert.Equal(t, tt.want.statusCode, w.Code)
 			assert.Contains(t, bearerToken, tt.want.auth)
+			assert.Equal(t, tt.want.retryAfter, res.Header.Get("Retry-After") != "")
 		})
 	}
 }
 
+func Test_HandlerLoginSuspended(t *testing.T) {
+	cfg, _ := InitTestConfig()
+	user := mocks.NewUserStorage(t)
+	loginEvents := mocks.NewLoginEventStorager(t)
+	hasher, _ := utils.InitPasswordHasher(cfg)
+
+	a := app.UserApp{User: user, LoginEvents: loginEvents, Cfg: cfg, Hasher: hasher}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	hash, _ := hasher.Hash(context.Background(), "password")
+
+	user.On("GetUser", mock.Anything, mock.Anything).Return(sharedTypes.User{UID: "1", Login: "tester", PasswordHash: hash, Suspended: true}, nil).Once()
+	loginEvents.On("StartLoginAttempt", mock.Anything, loginAttempt("tester"), mock.Anything).Return(startedAttempt, sharedTypes.LoginFailures{}, nil).Once()
+	loginEvents.On("FinishLoginAttempt", mock.Anything, mock.MatchedBy(func(e sharedTypes.LoginEvent) bool {
+		return e.ID == "1" && e.Outcome == app.LoginOutcomeSuspended && e.UID == "1"
+	})).Return(nil).Once()
+
+	body := bytes.NewBuffer([]byte{})
+	json.NewEncoder(body).Encode(sharedTypes.Credentials{Login: "tester", Password: "password"})
+
+	request := httptest.NewRequest(http.MethodPost, "/", body)
+	request.Header.Add("Content-type", "application/json")
+
+	w := httptest.NewRecorder()
+	hn.HandleLogin(w, request)
+
+	res := w.Result()
+	res.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, res.Header.Get("Authorization"))
+}
+
 func Test_RegisterAndLogin(t *testing.T) {
 	cfg, _ := InitTestConfig()
 
 	user := mocks.NewUserStorage(t)
 	withdrawal := mocks.NewWithdrawalStorage(t)
+	session := mocks.NewSessionStorager(t)
+	hasher, _ := utils.InitPasswordHasher(cfg)
+	policy, _ := utils.InitPasswordPolicy(cfg)
 
-	a := app.UserApp{User: user, Withdrawal: withdrawal, Cfg: cfg}
+	a := app.UserApp{User: user, Withdrawal: withdrawal, Session: session, Cfg: cfg, Hasher: hasher, Policy: policy}
 	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
 	user.On("CreateUser", mock.Anything, mock.Anything).Return("some_uid", nil)
+	session.On("CreateSession", mock.Anything, "some_uid", mock.Anything, mock.Anything).Return("1", nil)
 
 	t.Run("Check login after register", func(t *testing.T) {
 		body := bytes.NewBuffer([]byte{})
@@ -262,18 +355,35 @@ func Test_HandleGetBalance(t *testing.T) {
 				result: []interface{}{sharedTypes.Balance{Current: 4510, Withdrawn: 300}, nil},
 			},
 		},
+		{
+			name: "Balance with pending accrual",
+			uid:  "2",
+			want: want{
+				statusCode:   http.StatusOK,
+				responseBody: sharedTypes.Balance{Current: 10, Withdrawn: 5, Pending: 42.5},
+			},
+			mockData: mockSettings{
+				method: "GetBalance",
+				args:   []interface{}{mock.Anything, "2"},
+				result: []interface{}{sharedTypes.Balance{Current: 10, Withdrawn: 5, Pending: 42.5}, nil},
+			},
+		},
 	}
 	cfg, _ := InitTestConfig()
 
 	user := mocks.NewUserStorage(t)
 	withdrawal := mocks.NewWithdrawalStorage(t)
+	households := mocks.NewHouseholdStorager(t)
+	hasher, _ := utils.InitPasswordHasher(cfg)
+	policy, _ := utils.InitPasswordPolicy(cfg)
 
-	a := app.UserApp{User: user, Withdrawal: withdrawal, Cfg: cfg}
+	a := app.UserApp{User: user, Withdrawal: withdrawal, Households: households, Cfg: cfg, Hasher: hasher, Policy: policy}
 	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			user.On(tt.mockData.method, tt.mockData.args...).Return(tt.mockData.result...).Once()
+			households.On("GetMemberBalance", mock.Anything, tt.uid).Return(sharedTypes.HouseholdBalance{}, utils.ErrNotFound).Once()
 
 			request := httptest.NewRequest(http.MethodGet, "/", nil)
 
@@ -291,3 +401,381 @@ func Test_HandleGetBalance(t *testing.T) {
 		})
 	}
 }
+
+func Test_HandleListPendingAccruals(t *testing.T) {
+	tests := []struct {
+		name       string
+		uid        string
+		result     []sharedTypes.PendingAccrual
+		statusCode int
+	}{
+		{
+			name: "Pending orders listed",
+			uid:  "1",
+			result: []sharedTypes.PendingAccrual{
+				{Number: "12345678903", Status: "PROCESSING", Source: sharedTypes.PendingSourceAccrual, Accrual: 42.5},
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Nothing pending",
+			uid:        "2",
+			result:     []sharedTypes.PendingAccrual{},
+			statusCode: http.StatusNoContent,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	user := mocks.NewUserStorage(t)
+
+	a := app.UserApp{User: user, Cfg: cfg}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user.On("ListPendingAccruals", mock.Anything, tt.uid).Return(tt.result, nil).Once()
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, tt.uid)
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleListPendingAccruals(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusOK {
+				var list []sharedTypes.PendingAccrual
+				json.NewDecoder(w.Body).Decode(&list)
+
+				assert.Equal(t, tt.result, list)
+			}
+		})
+	}
+}
+
+func Test_HandleListExpiringPoints(t *testing.T) {
+	soon := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := soon.AddDate(0, 2, 0)
+
+	tests := []struct {
+		name     string
+		lifetime int
+		schedule []sharedTypes.PointsExpiry
+		want     sharedTypes.ExpiringPoints
+	}{
+		{
+			name:     "Points expire in two batches",
+			lifetime: 365,
+			schedule: []sharedTypes.PointsExpiry{{ExpiresAt: soon, Points: 40}, {ExpiresAt: later, Points: 2.5}},
+			want:     sharedTypes.ExpiringPoints{Total: 42.5, Schedule: []sharedTypes.PointsExpiry{{ExpiresAt: soon, Points: 40}, {ExpiresAt: later, Points: 2.5}}},
+		},
+		{
+			name:     "Nothing expires",
+			lifetime: 365,
+			schedule: []sharedTypes.PointsExpiry{},
+			want:     sharedTypes.ExpiringPoints{Schedule: []sharedTypes.PointsExpiry{}},
+		},
+		{
+			name:     "Expiry disabled",
+			lifetime: 0,
+			want:     sharedTypes.ExpiringPoints{Schedule: []sharedTypes.PointsExpiry{}},
+		},
+	}
+	cfg, _ := InitTestConfig()
+	lots := mocks.NewLotStorager(t)
+
+	a := app.UserApp{Lots: lots, Cfg: cfg}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.PointsLifetimeDays = tt.lifetime
+
+			if tt.lifetime > 0 {
+				lots.On("ListExpiringPoints", mock.Anything, "1", tt.lifetime).Return(tt.schedule, nil).Once()
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1")
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleListExpiringPoints(w, request)
+
+			var expiring sharedTypes.ExpiringPoints
+			json.NewDecoder(w.Body).Decode(&expiring)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.want, expiring)
+		})
+	}
+}
+
+func Test_HandleListAdjustments(t *testing.T) {
+	tests := []struct {
+		name       string
+		uid        string
+		result     []sharedTypes.Adjustment
+		statusCode int
+	}{
+		{
+			name: "Adjustments listed",
+			uid:  "1",
+			result: []sharedTypes.Adjustment{
+				{ID: "1", Amount: 100, Reason: "goodwill", Comment: "late delivery"},
+				{ID: "2", Amount: -20, Reason: "correction", Comment: "duplicate accrual"},
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "No adjustments",
+			uid:        "2",
+			result:     []sharedTypes.Adjustment{},
+			statusCode: http.StatusNoContent,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	adjustment := mocks.NewAdjustmentStorager(t)
+
+	a := app.UserApp{Adjustment: adjustment, Cfg: cfg}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adjustment.On("ListAdjustments", mock.Anything, tt.uid).Return(tt.result, nil).Once()
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			ctx := context.WithValue(request.Context(), sharedTypes.UIDKey{}, tt.uid)
+			request = request.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			hn.HandleListAdjustments(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleRegisterWithReferral(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		referrer   sharedTypes.Referrer
+		found      error
+		created    error
+		statusCode int
+	}{
+		{
+			name:       "Referred sign-up",
+			code:       "abcd-efgh",
+			referrer:   sharedTypes.Referrer{UID: "3"},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Unknown code",
+			code:       "ZZZZZZZZ",
+			found:      utils.ErrReferralCode,
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Code over its daily limit",
+			code:       "ABCDEFGH",
+			referrer:   sharedTypes.Referrer{UID: "3"},
+			created:    utils.ErrReferralLimit,
+			statusCode: http.StatusTooManyRequests,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	referrals := mocks.NewReferralStorager(t)
+	session := mocks.NewSessionStorager(t)
+	hasher, _ := utils.InitPasswordHasher(cfg)
+	policy, _ := utils.InitPasswordPolicy(cfg)
+
+	a := app.UserApp{Referrals: referrals, Session: session, Cfg: cfg, Hasher: hasher, Policy: policy}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			referrals.On("GetReferrer", mock.Anything, utils.NormalizeCode(tt.code)).Return(tt.referrer, tt.found).Once()
+
+			if tt.found == nil {
+				referrals.On("CreateReferredUser", mock.Anything, mock.MatchedBy(func(c sharedTypes.Credentials) bool {
+					return c.Login == "invitee"
+				}), "3", cfg.ReferralDailyLimit).Return("8", tt.created).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				session.On("CreateSession", mock.Anything, "8", mock.Anything, mock.Anything).Return("1", nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(sharedTypes.Credentials{Login: "invitee", Password: "password", ReferralCode: tt.code})
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			request.Header.Add("Content-type", "application/json")
+			w := httptest.NewRecorder()
+			hn.HandleRegister(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+		})
+	}
+}
+
+func Test_HandleListReferrals(t *testing.T) {
+	registered := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	referrals := []sharedTypes.Referral{
+		{ID: "1", Login: "alice", Status: sharedTypes.ReferralStatusRewarded, ReferrerBonus: 100, CreatedAt: registered},
+		{ID: "2", Login: "bo", Status: sharedTypes.ReferralStatusRejected, Reason: sharedTypes.ReferralReasonMinAccrual, CreatedAt: registered},
+		{ID: "3", Login: "carol", Status: sharedTypes.ReferralStatusPending, CreatedAt: registered},
+	}
+
+	cfg, _ := InitTestConfig()
+	storage := mocks.NewReferralStorager(t)
+
+	a := app.UserApp{Referrals: storage, Cfg: cfg}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	// the code is handed out on first use
+	storage.On("GetReferralCode", mock.Anything, "1").Return("", nil).Once()
+	storage.On("SetReferralCode", mock.Anything, "1", mock.AnythingOfType("string")).Return("K7MPQ2XD", nil).Once()
+	storage.On("ListReferrals", mock.Anything, "1").Return(referrals, nil).Once()
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request = request.WithContext(context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1"))
+
+	w := httptest.NewRecorder()
+	hn.HandleListReferrals(w, request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	got := sharedTypes.ReferralSummary{}
+	json.NewDecoder(w.Body).Decode(&got)
+
+	assert.Equal(t, "K7MPQ2XD", got.Code)
+	assert.Equal(t, float32(100), got.Earned)
+	assert.Equal(t, []string{"al***", "***", "ca***"}, []string{got.Referrals[0].Login, got.Referrals[1].Login, got.Referrals[2].Login})
+	assert.Equal(t, sharedTypes.ReferralReasonMinAccrual, got.Referrals[1].Reason)
+}
+
+func Test_HandleBalanceTransfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    sharedTypes.TransferRequest
+		recipient  string
+		found      error
+		balance    sharedTypes.Balance
+		stored     error
+		statusCode int
+	}{
+		{
+			name:       "Points gifted",
+			request:    sharedTypes.TransferRequest{Login: "grandma", Amount: 150},
+			recipient:  "2",
+			balance:    sharedTypes.Balance{Current: 500},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Unknown recipient",
+			request:    sharedTypes.TransferRequest{Login: "nobody", Amount: 150},
+			found:      utils.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Transfer to self",
+			request:    sharedTypes.TransferRequest{Login: "me", Amount: 150},
+			recipient:  "1",
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Below minimum amount",
+			request:    sharedTypes.TransferRequest{Login: "grandma", Amount: 5},
+			statusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Not enough points",
+			request:    sharedTypes.TransferRequest{Login: "grandma", Amount: 150},
+			recipient:  "2",
+			balance:    sharedTypes.Balance{Current: 100},
+			statusCode: http.StatusPaymentRequired,
+		},
+		{
+			name:       "Frozen sender",
+			request:    sharedTypes.TransferRequest{Login: "grandma", Amount: 150},
+			recipient:  "2",
+			balance:    sharedTypes.Balance{Current: 500, Frozen: true},
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Daily limit reached",
+			request:    sharedTypes.TransferRequest{Login: "grandma", Amount: 150},
+			recipient:  "2",
+			balance:    sharedTypes.Balance{Current: 500},
+			stored:     utils.ErrTransferLimit,
+			statusCode: http.StatusTooManyRequests,
+		},
+	}
+	cfg, _ := InitTestConfig()
+	user := mocks.NewUserStorager(t)
+	transfers := mocks.NewTransferStorager(t)
+	audit := mocks.NewAuditStorager(t)
+
+	a := app.UserApp{User: user, Transfers: transfers, Cfg: cfg, UserLocks: &sync.Map{}, Auditor: &app.Auditor{Audit: audit}}
+	hn := handler.InitUserHandler(&a, cfg, &zap.SugaredLogger{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.recipient != "" || tt.found != nil {
+				transfers.On("GetRecipient", mock.Anything, tt.request.Login).Return(tt.recipient, tt.found).Once()
+			}
+
+			if tt.recipient != "" && tt.recipient != "1" {
+				user.On("GetBalance", mock.Anything, "1").Return(tt.balance, nil).Once()
+			}
+
+			if tt.statusCode == http.StatusOK || tt.stored != nil {
+				limits := sharedTypes.TransferLimits{Amount: cfg.TransferDailyLimit, Count: cfg.TransferDailyCount}
+
+				transfers.On("TransferPoints", mock.Anything, sharedTypes.Transfer{SenderUID: "1", RecipientUID: "2", Amount: tt.request.Amount}, limits).
+					Return(sharedTypes.Transfer{ID: "4", SenderUID: "1", RecipientUID: "2", Amount: tt.request.Amount}, tt.stored).Once()
+			}
+
+			if tt.statusCode == http.StatusOK {
+				audit.On("AppendAudit", mock.Anything, mock.MatchedBy(func(e sharedTypes.AuditEntry) bool {
+					return e.Action == app.AuditUserTransfer && e.Target == "user:1"
+				})).Return(nil).Once()
+			}
+
+			body := bytes.NewBuffer([]byte{})
+			json.NewEncoder(body).Encode(tt.request)
+
+			request := httptest.NewRequest(http.MethodPost, "/", body)
+			request = request.WithContext(context.WithValue(request.Context(), sharedTypes.UIDKey{}, "1"))
+
+			w := httptest.NewRecorder()
+			hn.HandleBalanceTransfer(w, request)
+
+			assert.Equal(t, tt.statusCode, w.Code)
+
+			if tt.statusCode == http.StatusOK {
+				got := sharedTypes.Transfer{}
+				json.NewDecoder(w.Body).Decode(&got)
+
+				assert.Equal(t, sharedTypes.Transfer{ID: "4", Direction: sharedTypes.TransferSent, Login: "grandma", Amount: 150}, got)
+			}
+		})
+	}
+}
+
+func loginAttempt(login string) interface{} {
+	return mock.MatchedBy(func(e sharedTypes.LoginEvent) bool { return e.Login == login })
+}
+
+// startedAttempt hands the event back the way StartLoginAttempt records it.
+func startedAttempt(_ context.Context, event sharedTypes.LoginEvent, _ uint) sharedTypes.LoginEvent {
+	event.ID = "1"
+
+	return event
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/T-V-N/gopherstore/internal/app"
 	"github.com/T-V-N/gopherstore/internal/handler"
+	"github.com/T-V-N/gopherstore/internal/utils"
 	"go.uber.org/zap"
 
 	"github.com/T-V-N/gopherstore/mocks"
@@ -56,10 +57,31 @@ func Test_HandleWithdrawBalance(t *testing.T) {
 				args:       []interface{}{mock.Anything, "1337"},
 				result:     []interface{}{sharedTypes.Balance{Current: float32(334), Withdrawn: float32(300)}, nil},
 			}, {
-				storageTyp: "withdrawal",
+				storageTyp: "user",
 				method:     "WithdrawBalance",
-				args:       []interface{}{mock.Anything, "1337", "12345678903", float32(333), float32(1), float32(633)},
-				result:     []interface{}{nil, nil},
+				args:       []interface{}{mock.Anything, "1337", "12345678903", float32(333)},
+				result:     []interface{}{sharedTypes.Balance{Current: float32(1), Withdrawn: float32(633)}, nil},
+			},
+			},
+		},
+		{
+			name:        "Order number already used",
+			uid:         "1337",
+			contentType: "application/json",
+			body:        sharedTypes.WtihdrawRequest{OrderID: "12345678903", Sum: 333},
+			want: want{
+				statusCode: http.StatusConflict,
+			},
+			mockData: []mockSettings{{
+				storageTyp: "user",
+				method:     "GetBalance",
+				args:       []interface{}{mock.Anything, "1337"},
+				result:     []interface{}{sharedTypes.Balance{Current: float32(334), Withdrawn: float32(300)}, nil},
+			}, {
+				storageTyp: "user",
+				method:     "WithdrawBalance",
+				args:       []interface{}{mock.Anything, "1337", "12345678903", float32(333)},
+				result:     []interface{}{sharedTypes.Balance{}, utils.ErrDuplicate},
 			},
 			},
 		},
@@ -79,6 +101,22 @@ func Test_HandleWithdrawBalance(t *testing.T) {
 			},
 			},
 		},
+		{
+			name:        "Frozen account",
+			uid:         "1337",
+			contentType: "application/json",
+			body:        sharedTypes.WtihdrawRequest{OrderID: "12345678903", Sum: 333},
+			want: want{
+				statusCode: http.StatusForbidden,
+			},
+			mockData: []mockSettings{{
+				storageTyp: "user",
+				method:     "GetBalance",
+				args:       []interface{}{mock.Anything, "1337"},
+				result:     []interface{}{sharedTypes.Balance{Current: float32(1000), Withdrawn: float32(0), Frozen: true}, nil},
+			},
+			},
+		},
 		{
 			name:        "Wrong order number",
 			uid:         "1337",
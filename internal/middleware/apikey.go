@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// InitAPIKeyAuth authenticates partner clients. Unlike InitAuth it does not
+// identify a user: partners name the target user in each request.
+func InitAPIKeyAuth(cfg *config.Config, keys sharedTypes.APIKeyStorager) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Header.Get(APIKeyHeader)
+
+			prefix, ok := utils.APIKeyPrefix(raw)
+			if !ok {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			key, err := keys.GetAPIKey(r.Context(), prefix)
+
+			if errors.Is(err, utils.ErrNotFound) {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			if subtle.ConstantTimeCompare([]byte(utils.HashAPIKey(raw)), []byte(key.Hash)) != 1 {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			count, reset, err := keys.HitRateLimit(r.Context(), key.ID, cfg.PartnerRateWindow)
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			if count > key.RateLimit {
+				w.Header().Set("Retry-After", strconv.Itoa(reset))
+				http.Error(w, utils.ErrTooManyTries.Error(), http.StatusTooManyRequests)
+
+				return
+			}
+
+			partner := sharedTypes.Partner{KeyID: key.ID, Scopes: key.Scopes}
+			ctx := context.WithValue(r.Context(), sharedTypes.PartnerKey{}, partner)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func RequireScope(scope string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			partner, _ := r.Context().Value(sharedTypes.PartnerKey{}).(sharedTypes.Partner)
+
+			if !utils.HasScope(partner.Scopes, scope) {
+				http.Error(w, utils.ErrForbidden.Error(), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
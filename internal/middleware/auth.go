@@ -11,7 +11,7 @@ import (
 	"github.com/T-V-N/gopherstore/internal/utils"
 )
 
-func InitAuth(cfg *config.Config) func(next http.Handler) http.Handler {
+func InitAuth(cfg *config.Config, sessions sharedTypes.SessionStorager) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token := r.Header.Get("Authorization")
@@ -27,16 +27,48 @@ func InitAuth(cfg *config.Config) func(next http.Handler) http.Handler {
 				return
 			}
 
-			uid, err := auth.ParseToken(headerParts[1], []byte(cfg.SecretKey))
+			claims, err := auth.ParseToken(headerParts[1], []byte(cfg.SecretKey))
 
-			if err != nil || uid == "" {
-				http.Error(w, err.Error(), http.StatusUnauthorized)
+			if err != nil || claims.UID == "" {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			role, active, err := sessions.TouchSession(r.Context(), claims.SID, claims.UID)
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			if !active {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), sharedTypes.UIDKey{}, uid)
+			ctx := context.WithValue(r.Context(), sharedTypes.UIDKey{}, claims.UID)
+			ctx = context.WithValue(ctx, sharedTypes.SIDKey{}, claims.SID)
+			// the stored role wins over the claim so a demotion takes effect at once
+			ctx = context.WithValue(ctx, sharedTypes.RoleKey{}, role)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+func RequireRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value(sharedTypes.RoleKey{}).(string)
+
+			for _, allowed := range roles {
+				if role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			http.Error(w, utils.ErrForbidden.Error(), http.StatusForbidden)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	chiMw "github.com/go-chi/chi/v5/middleware"
+)
+
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		client := sharedTypes.Client{IP: ip, UserAgent: r.UserAgent(), RequestID: chiMw.GetReqID(r.Context())}
+
+		if client.RequestID != "" {
+			w.Header().Set(chiMw.RequestIDHeader, client.RequestID)
+		}
+
+		ctx := context.WithValue(r.Context(), sharedTypes.ClientKey{}, client)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
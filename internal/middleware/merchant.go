@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+)
+
+const MerchantKeyHeader = "X-Merchant-Key"
+
+func InitMerchantAuth(merchants sharedTypes.MerchantStorager) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := r.Header.Get(MerchantKeyHeader)
+
+			prefix, ok := utils.MerchantKeyPrefix(raw)
+			if !ok {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			merchant, err := merchants.GetMerchant(r.Context(), prefix)
+
+			if errors.Is(err, utils.ErrNotFound) {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			if subtle.ConstantTimeCompare([]byte(utils.HashAPIKey(raw)), []byte(merchant.Hash)) != 1 {
+				http.Error(w, utils.ErrNotAuthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), sharedTypes.MerchantKey{}, merchant.ID)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
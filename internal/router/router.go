@@ -6,30 +6,123 @@ import (
 	"github.com/T-V-N/gopherstore/internal/config"
 	"github.com/T-V-N/gopherstore/internal/handler"
 	"github.com/T-V-N/gopherstore/internal/middleware"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
 	"github.com/go-chi/chi/v5"
 	chiMw "github.com/go-chi/chi/v5/middleware"
 )
 
 func InitRouter(cfg *config.Config,
 	authMw func(next http.Handler) http.Handler,
+	apiKeyMw func(next http.Handler) http.Handler,
+	merchantMw func(next http.Handler) http.Handler,
 	userHn *handler.UserHandler,
 	orderHn *handler.OrderHandler,
-	withdrawalHn *handler.WithdrawalHandler) chi.Router {
+	withdrawalHn *handler.WithdrawalHandler,
+	sessionHn *handler.SessionHandler,
+	adminHn *handler.AdminHandler,
+	partnerHn *handler.PartnerHandler,
+	merchantHn *handler.MerchantHandler,
+	disputeHn *handler.DisputeHandler,
+	tierHn *handler.TierHandler,
+	promoHn *handler.PromoHandler,
+	householdHn *handler.HouseholdHandler) chi.Router {
 	router := chi.NewRouter()
 	router.Use(chiMw.Compress(cfg.CompressLevel))
 	router.Use(middleware.GzipHandle)
 
+	if cfg.TrustProxyHeaders {
+		router.Use(chiMw.RealIP)
+	}
+
+	router.Use(chiMw.RequestID)
+	router.Use(middleware.ClientInfo)
+
 	router.Route("/api/user", func(userRouter chi.Router) {
 		userRouter.Post("/login", userHn.HandleLogin)
+		userRouter.Post("/login/2fa", userHn.HandleLoginTwoFactor)
 		userRouter.Post("/register", userHn.HandleRegister)
 		userRouter.Group(func(r chi.Router) {
 			r.Use(authMw)
+			r.Delete("/", userHn.HandleDeleteAccount)
+			r.Get("/data", userHn.HandleExportData)
+			r.Post("/2fa/enroll", userHn.HandleEnrollTOTP)
+			r.Post("/2fa/confirm", userHn.HandleConfirmTOTP)
+			r.Delete("/2fa", userHn.HandleDisableTOTP)
 			r.Post("/orders", orderHn.HandleCreateOrder)
+			r.Post("/orders/batch", orderHn.HandleCreateOrders)
+			r.Delete("/orders/{number}", orderHn.HandleCancelOrder)
+			r.Post("/disputes", disputeHn.HandleOpenDispute)
+			r.Get("/disputes", disputeHn.HandleListUserDisputes)
 			r.Get("/orders", orderHn.HandleListOrder)
 			r.Get("/balance", userHn.HandleGetBalance)
+			r.Get("/balance/pending", userHn.HandleListPendingAccruals)
+			r.Get("/balance/expiring", userHn.HandleListExpiringPoints)
+			r.Get("/tier", tierHn.HandleGetTier)
+			r.Post("/promo", promoHn.HandleRedeemPromo)
 			r.Post("/balance/withdraw", userHn.HandleBalanceWithdraw)
+			r.Post("/balance/transfer", userHn.HandleBalanceTransfer)
+			r.Get("/transfers", userHn.HandleListTransfers)
+			r.Post("/household", householdHn.HandleCreateHousehold)
+			r.Get("/household", householdHn.HandleGetHousehold)
+			r.Delete("/household", householdHn.HandleDissolveHousehold)
+			r.Post("/household/invites", householdHn.HandleInviteMember)
+			r.Get("/household/invites", householdHn.HandleListInvites)
+			r.Post("/household/invites/{id}/accept", householdHn.HandleAcceptInvite)
+			r.Delete("/household/invites/{id}", householdHn.HandleDeclineInvite)
+			r.Put("/household/members/{uid}/limit", householdHn.HandleSetSpendLimit)
+			r.Delete("/household/members/{uid}", householdHn.HandleRemoveMember)
 			r.Get("/withdrawals", withdrawalHn.HandleListWithdrawals)
+			r.Get("/adjustments", userHn.HandleListAdjustments)
+			r.Get("/referrals", userHn.HandleListReferrals)
+			r.Get("/sessions", sessionHn.HandleListSessions)
+			r.Delete("/sessions/{id}", sessionHn.HandleRevokeSession)
+		})
+	})
+
+	router.Route("/api/admin", func(adminRouter chi.Router) {
+		adminRouter.Use(authMw)
+		adminRouter.Use(middleware.RequireRole(sharedTypes.RoleSupport, sharedTypes.RoleAdmin))
+		adminRouter.Get("/users", adminHn.HandleSearchUsers)
+		adminRouter.Get("/users/{uid}", adminHn.HandleGetUser)
+		adminRouter.Post("/users/{uid}/adjustments", adminHn.HandleCreateAdjustment)
+		adminRouter.Post("/users/{uid}/suspension", adminHn.HandleSuspension)
+		adminRouter.Delete("/users/{uid}/suspension", adminHn.HandleSuspension)
+		adminRouter.Post("/users/{uid}/freeze", adminHn.HandleFreeze)
+		adminRouter.Delete("/users/{uid}/freeze", adminHn.HandleFreeze)
+		adminRouter.Put("/users/{uid}/card", adminHn.HandleSetCardID)
+		adminRouter.Get("/disputes", disputeHn.HandleListDisputes)
+		adminRouter.Group(func(r chi.Router) {
+			r.Use(middleware.RequireRole(sharedTypes.RoleAdmin))
+			r.Post("/disputes/{id}/resolve", disputeHn.HandleResolveDispute)
+			r.Post("/users/merge", adminHn.HandleMergeUsers)
+			r.Get("/audit", adminHn.HandleListAudit)
+			r.Get("/audit/verify", adminHn.HandleVerifyAudit)
+			r.Post("/api-keys", adminHn.HandleCreateAPIKey)
+			r.Get("/api-keys", adminHn.HandleListAPIKeys)
+			r.Delete("/api-keys/{id}", adminHn.HandleRevokeAPIKey)
+			r.Post("/merchants", adminHn.HandleCreateMerchant)
+			r.Get("/merchants", adminHn.HandleListMerchants)
+			r.Delete("/merchants/{id}", adminHn.HandleRevokeMerchant)
+			r.Post("/campaigns", adminHn.HandleCreateCampaign)
+			r.Get("/campaigns", adminHn.HandleListCampaigns)
+			r.Delete("/campaigns/{id}", adminHn.HandleEndCampaign)
+			r.Post("/promo-codes", promoHn.HandleCreatePromoCode)
+			r.Get("/promo-codes", promoHn.HandleListPromoCodes)
+			r.Delete("/promo-codes/{id}", promoHn.HandleRevokePromoCode)
 		})
+		adminRouter.Get("/orders/{number}", adminHn.HandleGetOrder)
+	})
+
+	router.Route("/api/partner/users/{uid}", func(partnerRouter chi.Router) {
+		partnerRouter.Use(apiKeyMw)
+		partnerRouter.With(middleware.RequireScope(sharedTypes.ScopeOrdersWrite)).Post("/orders", partnerHn.HandleCreateOrder)
+		partnerRouter.With(middleware.RequireScope(sharedTypes.ScopeBalanceRead)).Get("/balance", partnerHn.HandleGetBalance)
+	})
+
+	router.Route("/api/merchant", func(merchantRouter chi.Router) {
+		merchantRouter.Use(merchantMw)
+		merchantRouter.Post("/orders", merchantHn.HandleCreateOrder)
+		merchantRouter.Post("/orders/{number}/refund", merchantHn.HandleRefundOrder)
 	})
 
 	return router
@@ -45,7 +45,8 @@ func checkOrder(orderID, status string, logger *zap.SugaredLogger, cfg config.Co
 		return
 	}
 
-	if o.Status != status {
+	// an in-flight order may already carry its expected accrual
+	if o.Status != status || o.Accrual > 0 {
 		err = order.UpdateOrder(ctx, orderID, o.Status, o.Accrual, user)
 		if err != nil {
 			logger.Errorw("Error while updating order data",
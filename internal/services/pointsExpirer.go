@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"go.uber.org/zap"
+)
+
+// InitExpirer periodically expires points older than the configured lifetime.
+// Each tick works through every user that has expired points.
+func InitExpirer(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger, User sharedTypes.UserApper) {
+	if cfg.PointsLifetimeDays <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(cfg.PointsExpiryInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			expired, err := User.ExpirePoints(ctx)
+
+			if err != nil {
+				logger.Errorw("Error while expiring points",
+					"err", err,
+				)
+			}
+
+			if expired > 0 {
+				logger.Infow("points expired",
+					"users", expired,
+				)
+			}
+		case <-ctx.Done():
+			logger.Info("Points expirer stopped")
+
+			return
+		}
+	}
+}
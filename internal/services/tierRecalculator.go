@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"go.uber.org/zap"
+)
+
+// tierRecalcCheck is how often an instance asks whether a recalculation is
+// due. The schedule itself lives in the database, so restarts do not reset it.
+const tierRecalcCheck = time.Minute
+
+// InitTierRecalculator re-evaluates every user's tier once per interval,
+// nightly by default, on one instance at a time. It checks at startup and
+// then every tierRecalcCheck.
+func InitTierRecalculator(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger, Tier sharedTypes.TierApper) {
+	ticker := time.NewTicker(tierRecalcCheck)
+	defer ticker.Stop()
+
+	for {
+		changed, ran, err := Tier.RecalculateTiersIfDue(ctx)
+
+		if err != nil {
+			logger.Errorw("Error while recalculating tiers",
+				"err", err,
+			)
+		}
+
+		if ran {
+			logger.Infow("tiers recalculated",
+				"changed", changed,
+			)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			logger.Info("Tier recalculator stopped")
+
+			return
+		}
+	}
+}
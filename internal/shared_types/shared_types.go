@@ -2,29 +2,306 @@ package sharedtypes
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
+const (
+	RoleUser    = "user"
+	RoleSupport = "support"
+	RoleAdmin   = "admin"
+)
+
+const (
+	OrderStatusNew        = "NEW"
+	OrderStatusRegistered = "REGISTERED"
+	OrderStatusProcessed  = "PROCESSED"
+)
+
+const (
+	CampaignRewardFixed   = "fixed"
+	CampaignRewardPercent = "percent"
+)
+
+const (
+	ReferralStatusPending  = "pending"
+	ReferralStatusRewarded = "rewarded"
+	ReferralStatusRejected = "rejected"
+)
+
+const (
+	ReferralReasonExpired    = "expired"
+	ReferralReasonMinAccrual = "below_min_accrual"
+	ReferralReasonCapReached = "cap_reached"
+	ReferralReasonInactive   = "referrer_inactive"
+)
+
+const (
+	TransferSent     = "sent"
+	TransferReceived = "received"
+)
+
+const (
+	BalanceSourcePersonal  = "personal"
+	BalanceSourceHousehold = "household"
+)
+
+const (
+	PendingSourceAccrual = "accrual"
+	PendingSourceRules   = "rules"
+)
+
+// A clawback policy decides what happens when a refund takes back more points
+// than the customer still holds.
+const (
+	ClawbackPolicyNegative = "negative"
+	ClawbackPolicyFloor    = "floor"
+	ClawbackPolicyReject   = "reject"
+)
+
+const (
+	DisputeStatusOpen       = "open"
+	DisputeStatusReassigned = "reassigned"
+	DisputeStatusRejected   = "rejected"
+)
+
+const (
+	ScopeOrdersWrite = "orders:write"
+	ScopeBalanceRead = "balance:read"
+)
+
 type Credentials struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login        string `json:"login"`
+	Password     string `json:"password"`
+	ReferralCode string `json:"referral_code,omitempty"`
 }
 
 type Order struct {
-	Number     string    `json:"number"`
-	Status     string    `json:"status"`
-	Accrual    float32   `json:"accrual,omitempty"`
-	UploadedAt time.Time `json:"uploaded_at"`
+	Number      string     `json:"number"`
+	Status      string     `json:"status"`
+	Accrual     float32    `json:"accrual,omitempty"`
+	Bonus       float32    `json:"bonus,omitempty"`
+	UploadedAt  time.Time  `json:"uploaded_at"`
+	Total       float32    `json:"total,omitempty"`
+	PurchasedAt *time.Time `json:"purchased_at,omitempty"`
+	NetAccrual  float32    `json:"net_accrual,omitempty"`
+	Refunded    float32    `json:"refunded,omitempty"`
 }
 
 type Balance struct {
-	Current   float32 `json:"current"`
-	Withdrawn float32 `json:"withdrawn"`
+	Current   float32           `json:"current"`
+	Withdrawn float32           `json:"withdrawn"`
+	Pending   float32           `json:"pending"`
+	Frozen    bool              `json:"frozen,omitempty"`
+	Household *HouseholdBalance `json:"household,omitempty"`
+}
+
+// HouseholdBalance is the pool as one member sees it. Spent counts what the
+// member took from the pool this calendar month.
+type HouseholdBalance struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Current    float32  `json:"current"`
+	SpendLimit *float32 `json:"spend_limit,omitempty"`
+	Spent      float32  `json:"spent"`
+}
+
+// PendingAccrual is an order still in flight whose accrual is already known,
+// either from the accrual system or from our own rules.
+type PendingAccrual struct {
+	UploadedAt time.Time `json:"uploaded_at"`
+	Number     string    `json:"order"`
+	Status     string    `json:"status"`
+	Source     string    `json:"source"`
+	Accrual    float32   `json:"accrual"`
+}
+
+type PointsExpiry struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Points    float32   `json:"points"`
+}
+
+type ExpiringPoints struct {
+	Total    float32        `json:"total"`
+	Schedule []PointsExpiry `json:"schedule"`
+}
+
+type Tier struct {
+	Name       string  `json:"name"`
+	MinAccrual float32 `json:"min_accrual"`
+	Multiplier float32 `json:"multiplier"`
+}
+
+type TierMember struct {
+	Since          time.Time
+	UID            string
+	Tier           string
+	RollingAccrual float32
+}
+
+type TierStatus struct {
+	Since           time.Time `json:"since"`
+	Tier            string    `json:"tier"`
+	NextTier        string    `json:"next_tier,omitempty"`
+	Multiplier      float32   `json:"multiplier"`
+	RollingAccrual  float32   `json:"rolling_accrual"`
+	NextTierAccrual float32   `json:"next_tier_accrual,omitempty"`
+	Remaining       float32   `json:"remaining,omitempty"`
+}
+
+// Campaign conditions left at their zero value do not restrict eligibility; a
+// zero UserCap means no cap.
+type Campaign struct {
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Tier        string    `json:"tier,omitempty"`
+	RewardType  string    `json:"reward_type"`
+	CreatedBy   string    `json:"-"`
+	FirstOrder  bool      `json:"first_order,omitempty"`
+	MinAccrual  float32   `json:"min_accrual,omitempty"`
+	RewardValue float32   `json:"reward_value"`
+	UserCap     float32   `json:"user_cap,omitempty"`
+	Rewarded    float32   `json:"rewarded"`
+	Granted     float32   `json:"-"`
+}
+
+type RewardSubject struct {
+	Tier       string
+	FirstOrder bool
+}
+
+type CampaignReward struct {
+	CreatedAt  time.Time `json:"created_at"`
+	ID         string    `json:"id"`
+	CampaignID string    `json:"campaign_id"`
+	UID        string    `json:"-"`
+	OrderID    string    `json:"order"`
+	Amount     float32   `json:"amount"`
+}
+
+// Code is only filled in the response that creates the promo code. A zero
+// MaxRedemptions means the code can be redeemed any number of times.
+type PromoCode struct {
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	ID             string     `json:"id"`
+	Code           string     `json:"code,omitempty"`
+	Hint           string     `json:"hint"`
+	Hash           string     `json:"-"`
+	CreatedBy      string     `json:"-"`
+	Amount         float32    `json:"amount"`
+	MaxRedemptions int        `json:"max_redemptions"`
+	PerUserLimit   int        `json:"per_user_limit"`
+	Redeemed       int        `json:"redeemed"`
+}
+
+type PromoRedeemRequest struct {
+	Code string `json:"code"`
+}
+
+type PromoRedemption struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	CodeID    string    `json:"-"`
+	Amount    float32   `json:"amount"`
+}
+
+// Login is masked before a referral is shown to the referrer.
+type Referral struct {
+	CreatedAt     time.Time  `json:"registered_at"`
+	ClosedAt      *time.Time `json:"closed_at,omitempty"`
+	ID            string     `json:"-"`
+	ReferrerUID   string     `json:"-"`
+	ReferredUID   string     `json:"-"`
+	Login         string     `json:"login"`
+	Status        string     `json:"status"`
+	Reason        string     `json:"reason,omitempty"`
+	OrderID       string     `json:"-"`
+	ReferrerBonus float32    `json:"reward,omitempty"`
+	RefereeBonus  float32    `json:"-"`
+}
+
+type ReferralSummary struct {
+	Code      string     `json:"code"`
+	Earned    float32    `json:"earned"`
+	Referrals []Referral `json:"referrals"`
+}
+
+type Referrer struct {
+	UID string
 }
 
 type WtihdrawRequest struct {
 	OrderID string  `json:"order"`
 	Sum     float32 `json:"sum"`
+	Source  string  `json:"source,omitempty"`
+}
+
+type Household struct {
+	CreatedAt time.Time         `json:"created_at"`
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	OwnerUID  string            `json:"-"`
+	Balance   float32           `json:"balance"`
+	Members   []HouseholdMember `json:"members"`
+}
+
+// A nil SpendLimit lets the member spend the whole pool; Spent is this
+// calendar month's spending.
+type HouseholdMember struct {
+	JoinedAt   time.Time `json:"joined_at"`
+	UID        string    `json:"uid"`
+	Login      string    `json:"login"`
+	Owner      bool      `json:"owner,omitempty"`
+	SpendLimit *float32  `json:"spend_limit,omitempty"`
+	Spent      float32   `json:"spent"`
+}
+
+type HouseholdInvite struct {
+	CreatedAt   time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	HouseholdID string    `json:"household_id"`
+	Household   string    `json:"household"`
+	Owner       string    `json:"owner"`
+	UID         string    `json:"-"`
+}
+
+type HouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+type HouseholdInviteRequest struct {
+	Login string `json:"login"`
+}
+
+type SpendLimitRequest struct {
+	Limit *float32 `json:"limit"`
+}
+
+type TransferRequest struct {
+	Login  string  `json:"login"`
+	Amount float32 `json:"amount"`
+}
+
+// Login is the other side of the transfer, as seen by the user listing it.
+type Transfer struct {
+	CreatedAt    time.Time `json:"created_at"`
+	ID           string    `json:"id"`
+	Direction    string    `json:"direction"`
+	Login        string    `json:"login"`
+	Amount       float32   `json:"amount"`
+	SenderUID    string    `json:"-"`
+	RecipientUID string    `json:"-"`
+}
+
+// TransferLimits cap what a sender can transfer in any 24 hours.
+type TransferLimits struct {
+	Amount float32
+	Count  int
 }
 
 type Withdrawal struct {
@@ -33,6 +310,16 @@ type Withdrawal struct {
 	ProcessedAt time.Time `json:"processed_at"`
 }
 
+type Adjustment struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	UID       string    `json:"-"`
+	AdminUID  string    `json:"-"`
+	Reason    string    `json:"reason"`
+	Comment   string    `json:"comment"`
+	Amount    float32   `json:"amount"`
+}
+
 type User struct {
 	UID            string
 	Login          string
@@ -40,18 +327,328 @@ type User struct {
 	CurrentBalance float32
 	Withdrawn      float32
 	CreatedAt      string
+	TOTPSecret     string
+	TOTPEnabled    bool
+	TOTPLastStep   int64
+	Role           string
+	Suspended      bool
+	Frozen         bool
+}
+
+type Session struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ID         string     `json:"id"`
+	IP         string     `json:"ip"`
+	UserAgent  string     `json:"user_agent"`
+	Current    bool       `json:"current"`
+}
+
+type Profile struct {
+	CreatedAt        time.Time `json:"created_at"`
+	UID              string    `json:"uid"`
+	Login            string    `json:"login"`
+	Balance          Balance   `json:"balance"`
+	TwoFactorEnabled bool      `json:"two_factor_enabled"`
+	CardID           string    `json:"card_id,omitempty"`
+}
+
+type UserData struct {
+	Profile     Profile      `json:"profile"`
+	Orders      []Order      `json:"orders"`
+	Withdrawals []Withdrawal `json:"withdrawals"`
+	Adjustments []Adjustment `json:"adjustments"`
+	Transfers   []Transfer   `json:"transfers"`
+	Sessions    []Session    `json:"sessions"`
+	LoginEvents []LoginEvent `json:"login_events"`
+}
+
+type AccountDeletion struct {
+	Password string `json:"password"`
+	Code     string `json:"code,omitempty"`
+}
+
+type LoginResult struct {
+	Token     string
+	Challenge string
+}
+
+type TwoFactorChallenge struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code,omitempty"`
+}
+
+type TwoFactorCode struct {
+	Code string `json:"code"`
+}
+
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+type UserSummary struct {
+	CreatedAt time.Time `json:"created_at"`
+	UID       string    `json:"uid"`
+	Login     string    `json:"login"`
+	Role      string    `json:"role"`
+	Balance   Balance   `json:"balance"`
+}
+
+type UserDetail struct {
+	UserSummary
+	TwoFactorEnabled bool    `json:"two_factor_enabled"`
+	Suspended        bool    `json:"suspended"`
+	Frozen           bool    `json:"frozen"`
+	CardID           string  `json:"card_id,omitempty"`
+	RecentOrders     []Order `json:"recent_orders"`
+}
+
+type MergeRequest struct {
+	SourceUID string `json:"source_uid"`
+	TargetUID string `json:"target_uid"`
+}
+
+type UserMerge struct {
+	CreatedAt        time.Time `json:"created_at"`
+	ID               string    `json:"id"`
+	SourceUID        string    `json:"source_uid"`
+	TargetUID        string    `json:"target_uid"`
+	AdminUID         string    `json:"-"`
+	MovedOrders      int       `json:"moved_orders"`
+	MovedWithdrawals int       `json:"moved_withdrawals"`
+	MovedBalance     float32   `json:"moved_balance"`
+	MovedWithdrawn   float32   `json:"moved_withdrawn"`
+}
+
+type APIKey struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Hash       string     `json:"-"`
+	CreatedBy  string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	RateLimit  int        `json:"rate_limit"`
+}
+
+type APIKeyRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit int      `json:"rate_limit,omitempty"`
+}
+
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type Merchant struct {
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Hash      string     `json:"-"`
+	CreatedBy string     `json:"-"`
+}
+
+type MerchantRequest struct {
+	Name string `json:"name"`
+}
+
+type MerchantCreated struct {
+	Merchant
+	Key string `json:"key"`
+}
+
+type Good struct {
+	Description string  `json:"description"`
+	Price       float32 `json:"price"`
+	Quantity    int     `json:"quantity,omitempty"`
+}
+
+// ExpectedAccrual is our own estimate, shown as pending until the accrual
+// system reports a figure.
+type OrderSubmission struct {
+	PurchasedAt     *time.Time `json:"purchased_at,omitempty"`
+	Number          string     `json:"order"`
+	Goods           []Good     `json:"goods"`
+	Total           float32    `json:"total,omitempty"`
+	ExpectedAccrual float32    `json:"-"`
+}
+
+type OrderResult struct {
+	Number string `json:"order"`
+	Status string `json:"status"`
+}
+
+// MerchantOrder names the customer either by login or by loyalty card.
+type MerchantOrder struct {
+	OrderSubmission
+	Login      string `json:"login,omitempty"`
+	CardID     string `json:"card_id,omitempty"`
+	MerchantID string `json:"-"`
+	UID        string `json:"-"`
+}
+
+type RefundRequest struct {
+	Amount float32 `json:"amount,omitempty"`
+}
+
+// RefundableOrder is a merchant's order as seen right before a refund.
+// Accrual includes the tier bonus.
+type RefundableOrder struct {
+	UID        string
+	Status     string
+	Accrual    float32
+	NetAccrual float32
+	Total      float32
+	Refunded   float32
+}
+
+// Refund reports what a refund took back. CampaignClawback is the share of the
+// order's campaign bonuses; WrittenOff covers both clawbacks.
+type Refund struct {
+	Number           string  `json:"order"`
+	UID              string  `json:"-"`
+	Amount           float32 `json:"amount"`
+	Clawback         float32 `json:"clawback"`
+	CampaignClawback float32 `json:"campaign_clawback,omitempty"`
+	WrittenOff       float32 `json:"written_off,omitempty"`
+	NetAccrual       float32 `json:"net_accrual"`
+}
+
+type CardRequest struct {
+	CardID string `json:"card_id"`
+}
+
+type Partner struct {
+	KeyID  string
+	Scopes []string
+}
+
+type OrderOwner struct {
+	Order
+	UID     string       `json:"uid"`
+	Login   string       `json:"login"`
+	History []OrderEvent `json:"history"`
+}
+
+type OrderEvent struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Event       string    `json:"event"`
+	Status      string    `json:"status"`
+	UID         string    `json:"uid"`
+	PreviousUID string    `json:"previous_uid,omitempty"`
+}
+
+// Dispute is shown to both parties; Role tells the viewer which side they are
+// on and the evidence is only returned to the claimant and admins.
+type Dispute struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	ID          string     `json:"id"`
+	Number      string     `json:"order"`
+	Status      string     `json:"status"`
+	Role        string     `json:"role,omitempty"`
+	Evidence    string     `json:"evidence,omitempty"`
+	Resolution  string     `json:"resolution,omitempty"`
+	ClaimantUID string     `json:"claimant_uid,omitempty"`
+	HolderUID   string     `json:"holder_uid,omitempty"`
+	ResolvedBy  string     `json:"-"`
+}
+
+type DisputeRequest struct {
+	Number   string `json:"order"`
+	Evidence string `json:"evidence"`
+}
+
+type DisputeResolution struct {
+	Decision string `json:"decision"`
+	Note     string `json:"note"`
+}
+
+type Client struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+type AuditEntry struct {
+	CreatedAt time.Time       `json:"created_at"`
+	ID        string          `json:"id"`
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Target    string          `json:"target"`
+	RequestID string          `json:"request_id,omitempty"`
+	IP        string          `json:"ip,omitempty"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+}
+
+type AuditFilter struct {
+	Actor    string
+	Action   string
+	Target   string
+	BeforeID int64
+	Limit    int
+}
+
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Checked  int    `json:"checked"`
+	BrokenAt string `json:"broken_at,omitempty"`
+}
+
+type LoginEvent struct {
+	ID        string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	Login     string    `json:"login"`
+	UID       string    `json:"-"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Outcome   string    `json:"outcome"`
+}
+
+type LoginFailures struct {
+	LoginLast  time.Time
+	IPLast     time.Time
+	LoginCount int
+	IPCount    int
 }
 
 type UserStorager interface {
 	CreateUser(context.Context, Credentials) (string, error)
 	GetUser(context.Context, Credentials) (User, error)
 	GetBalance(context.Context, string) (Balance, error)
-	WithdrawBalance(context.Context, string, string, float32, float32, float32, WithdrawalStorager) error
-	UpdateUser(context.Context, string, string, float32) error
+	ListPendingAccruals(context.Context, string) ([]PendingAccrual, error)
+	WithdrawBalance(ctx context.Context, uid, orderID string, amount float32) (Balance, error)
+	UpdateUser(ctx context.Context, uid, orderID string, accrual, bonus float32) error
+	UpdatePasswordHash(context.Context, string, string) error
+	GetUserByID(context.Context, string) (User, error)
+	SetTOTPSecret(context.Context, string, string) error
+	EnableTOTP(context.Context, string, int64, []string) error
+	DisableTOTP(context.Context, string) error
+	UseTOTPStep(context.Context, string, int64) (bool, error)
+	UseRecoveryCode(context.Context, string, string) (bool, error)
+	ExportUserData(context.Context, string) (UserData, error)
+	DeleteUser(context.Context, string) (string, error)
 }
 
 type OrderStorager interface {
 	CreateOrder(context.Context, string, string) error
+	CreateDetailedOrder(context.Context, string, string, OrderSubmission) error
+	CancelOrder(context.Context, string, string) error
 	ListOrders(context.Context, string) ([]Order, error)
 	GetUnproccessedOrders(context.Context) ([]Order, error)
 	UpdateOrder(context.Context, string, string, float32) (string, error)
@@ -62,23 +659,237 @@ type WithdrawalStorager interface {
 	CreateWithdrawal(context.Context, string, float32, string) error
 }
 
+type AdjustmentStorager interface {
+	CreateAdjustment(context.Context, Adjustment) (Adjustment, error)
+	ListAdjustments(context.Context, string) ([]Adjustment, error)
+}
+
+type TierStorager interface {
+	ListTiers(context.Context) ([]Tier, error)
+	GetTierMember(context.Context, string) (TierMember, error)
+	ListTierMembers(context.Context, string, int) ([]TierMember, error)
+	SetTier(context.Context, string, string) error
+	GetMultiplier(context.Context, string) (float32, error)
+}
+
+type JobStorager interface {
+	ClaimJob(ctx context.Context, name string, interval uint) (func(done bool) error, bool, error)
+}
+
+type CampaignStorager interface {
+	CreateCampaign(context.Context, Campaign) (Campaign, error)
+	ListCampaigns(context.Context) ([]Campaign, error)
+	ListActiveCampaigns(context.Context, string) ([]Campaign, error)
+	EndCampaign(context.Context, string) (bool, error)
+	GetRewardSubject(context.Context, string, string) (RewardSubject, error)
+	GrantReward(ctx context.Context, reward CampaignReward, userCap float32) (CampaignReward, bool, error)
+}
+
+type PromoStorager interface {
+	CreatePromoCode(context.Context, PromoCode) (PromoCode, error)
+	ListPromoCodes(context.Context) ([]PromoCode, error)
+	RevokePromoCode(context.Context, string) (bool, error)
+	RedeemPromoCode(ctx context.Context, uid, hash string) (PromoRedemption, error)
+}
+
+type ReferralStorager interface {
+	GetReferralCode(context.Context, string) (string, error)
+	SetReferralCode(ctx context.Context, uid, code string) (string, error)
+	GetReferrer(context.Context, string) (Referrer, error)
+	CreateReferredUser(ctx context.Context, creds Credentials, referrerUID string, dailyLimit int) (string, error)
+	ListReferrals(context.Context, string) ([]Referral, error)
+	GetPendingReferral(context.Context, string) (Referral, error)
+	RewardReferral(ctx context.Context, r Referral, maxRewards int) (Referral, error)
+	RejectReferral(ctx context.Context, id, reason string) error
+}
+
+type TransferStorager interface {
+	GetRecipient(ctx context.Context, login string) (string, error)
+	TransferPoints(ctx context.Context, t Transfer, limits TransferLimits) (Transfer, error)
+	ListTransfers(context.Context, string) ([]Transfer, error)
+}
+
+type HouseholdStorager interface {
+	CreateHousehold(ctx context.Context, ownerUID, name string) (Household, error)
+	GetHousehold(context.Context, string) (Household, error)
+	GetMemberBalance(context.Context, string) (HouseholdBalance, error)
+	CreateInvite(ctx context.Context, householdID, login string) (HouseholdInvite, error)
+	ListInvites(context.Context, string) ([]HouseholdInvite, error)
+	AcceptInvite(ctx context.Context, uid, id string) (string, error)
+	DeclineInvite(ctx context.Context, uid, id string) (bool, error)
+	RemoveMember(ctx context.Context, householdID, uid string) (bool, error)
+	SetSpendLimit(ctx context.Context, householdID, uid string, limit *float32) (bool, error)
+	DissolveHousehold(ctx context.Context, householdID, ownerUID string) (float32, error)
+	WithdrawHousehold(ctx context.Context, uid, orderID string, amount float32) (HouseholdBalance, error)
+}
+
+type LotStorager interface {
+	ConsumeLots(context.Context, string, float32) error
+	ListExpiringPoints(context.Context, string, int) ([]PointsExpiry, error)
+	ListUsersWithExpiredLots(ctx context.Context, lifetimeDays int, afterUID string, limit int) ([]string, error)
+	ExpireLots(context.Context, Adjustment, int) (Adjustment, error)
+	ListHouseholdsWithExpiredLots(ctx context.Context, lifetimeDays int, afterID string, limit int) ([]string, error)
+	ExpireHouseholdLots(ctx context.Context, householdID string, lifetimeDays int) (float32, error)
+}
+
+type AuditStorager interface {
+	AppendAudit(context.Context, AuditEntry) error
+	ListAudit(context.Context, AuditFilter) ([]AuditEntry, error)
+	ListAuditChain(context.Context, int64, int) ([]AuditEntry, error)
+}
+
+type APIKeyStorager interface {
+	CreateAPIKey(context.Context, APIKey) (APIKey, error)
+	ListAPIKeys(context.Context) ([]APIKey, error)
+	RevokeAPIKey(context.Context, string) (bool, error)
+	GetAPIKey(context.Context, string) (APIKey, error)
+	HitRateLimit(context.Context, string, uint) (int, int, error)
+}
+
+type LoginEventStorager interface {
+	StartLoginAttempt(ctx context.Context, event LoginEvent, window uint) (LoginEvent, LoginFailures, error)
+	FinishLoginAttempt(ctx context.Context, event LoginEvent) error
+}
+
+type SessionStorager interface {
+	CreateSession(context.Context, string, Client, int64) (string, error)
+	ListSessions(context.Context, string) ([]Session, error)
+	RevokeSession(context.Context, string, string) (bool, error)
+	RevokeUserSessions(context.Context, string) error
+	TouchSession(ctx context.Context, sid, uid string) (string, bool, error)
+}
+
+type AdminStorager interface {
+	SearchUsers(context.Context, string, int) ([]UserSummary, error)
+	GetUserDetail(context.Context, string, int) (UserDetail, error)
+	GetOrder(context.Context, string) (OrderOwner, error)
+	SetSuspended(context.Context, string, bool) (bool, error)
+	SetFrozen(context.Context, string, bool) (bool, error)
+	MergeUsers(context.Context, UserMerge) (UserMerge, error)
+	SetCardID(context.Context, string, string) (bool, error)
+}
+
+type DisputeStorager interface {
+	CreateDispute(context.Context, Dispute) (Dispute, error)
+	GetDispute(context.Context, string) (Dispute, error)
+	ListUserDisputes(context.Context, string) ([]Dispute, error)
+	ListDisputes(context.Context, string, int) ([]Dispute, error)
+	ResolveDispute(context.Context, Dispute, bool) (Dispute, error)
+}
+
+type MerchantStorager interface {
+	CreateMerchant(context.Context, Merchant) (Merchant, error)
+	ListMerchants(context.Context) ([]Merchant, error)
+	RevokeMerchant(context.Context, string) (bool, error)
+	GetMerchant(context.Context, string) (Merchant, error)
+	FindCustomer(context.Context, string, string) (string, bool, error)
+	GetRefundableOrder(context.Context, string, string) (RefundableOrder, error)
+	RefundOrder(ctx context.Context, refund Refund, prev RefundableOrder, policy string) (Refund, error)
+}
+
 type OrderApper interface {
 	GetUnproccessedOrders(ctx context.Context) ([]Order, error)
 	CreateOrder(ctx context.Context, orderID string, uid string) error
+	CreateMerchantOrder(ctx context.Context, order MerchantOrder) error
+	SubmitOrder(ctx context.Context, uid string, order OrderSubmission) error
+	CreateOrders(ctx context.Context, uid string, numbers []string) []OrderResult
+	CancelOrder(ctx context.Context, uid, orderID string) error
 	ListOrders(ctx context.Context, uid string) ([]Order, error)
 	UpdateOrder(ctx context.Context, uid, orderID string, amount float32, user UserApper) error
 }
 
 type OrderRegisterer interface {
-	RegisterOrder(ctx context.Context, orderID string) error
+	RegisterOrder(ctx context.Context, orderID string, goods []Good) error
 }
 
 type UserApper interface {
 	Register(ctx context.Context, creds Credentials) (string, error)
-	Login(ctx context.Context, creds Credentials) (string, error)
+	Login(ctx context.Context, creds Credentials) (LoginResult, error)
+	LoginTwoFactor(ctx context.Context, challenge, code string) (string, error)
+	EnrollTOTP(ctx context.Context, uid string) (TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, uid, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, uid, code string) error
+	ExportData(ctx context.Context, uid string) (UserData, error)
+	DeleteAccount(ctx context.Context, uid string, req AccountDeletion) error
 	GetBalance(ctx context.Context, uid string) (Balance, error)
-	WithdrawBalance(ctx context.Context, uid string, orderID string, amount float32) error
-	UpdateUser(ctx context.Context, uid, orderID string, amount float32) error
+	ListPendingAccruals(ctx context.Context, uid string) ([]PendingAccrual, error)
+	ListExpiringPoints(ctx context.Context, uid string) (ExpiringPoints, error)
+	ExpirePoints(ctx context.Context) (int, error)
+	ListAdjustments(ctx context.Context, uid string) ([]Adjustment, error)
+	ListReferrals(ctx context.Context, uid string) (ReferralSummary, error)
+	WithdrawBalance(ctx context.Context, uid string, orderID string, amount float32, source string) error
+	TransferBalance(ctx context.Context, uid string, req TransferRequest) (Transfer, error)
+	ListTransfers(ctx context.Context, uid string) ([]Transfer, error)
+	UpdateUser(ctx context.Context, uid, orderID string, accrual, bonus float32) error
+}
+
+type SessionApper interface {
+	ListSessions(ctx context.Context, uid, currentSID string) ([]Session, error)
+	RevokeSession(ctx context.Context, uid, sid string) error
+}
+
+type AdminApper interface {
+	SearchUsers(ctx context.Context, login string) ([]UserSummary, error)
+	GetUser(ctx context.Context, uid string) (UserDetail, error)
+	GetOrder(ctx context.Context, number string) (OrderOwner, error)
+	CreateAdjustment(ctx context.Context, adminUID string, adj Adjustment) (Adjustment, error)
+	SetSuspended(ctx context.Context, uid string, suspended bool) error
+	SetFrozen(ctx context.Context, uid string, frozen bool) error
+	MergeUsers(ctx context.Context, adminUID string, req MergeRequest) (UserMerge, error)
+	CreateAPIKey(ctx context.Context, adminUID string, req APIKeyRequest) (APIKeyCreated, error)
+	ListAPIKeys(ctx context.Context) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	CreateMerchant(ctx context.Context, adminUID string, req MerchantRequest) (MerchantCreated, error)
+	ListMerchants(ctx context.Context) ([]Merchant, error)
+	RevokeMerchant(ctx context.Context, id string) error
+	CreateCampaign(ctx context.Context, adminUID string, c Campaign) (Campaign, error)
+	ListCampaigns(ctx context.Context) ([]Campaign, error)
+	EndCampaign(ctx context.Context, id string) error
+	SetCardID(ctx context.Context, uid, cardID string) error
+	ListAudit(ctx context.Context, filter AuditFilter) ([]AuditEntry, error)
+	VerifyAudit(ctx context.Context) (AuditVerification, error)
+}
+
+type PartnerApper interface {
+	CreateOrder(ctx context.Context, uid, orderID string) error
+	GetBalance(ctx context.Context, uid string) (Balance, error)
+}
+
+type DisputeApper interface {
+	OpenDispute(ctx context.Context, uid string, req DisputeRequest) (Dispute, error)
+	ListUserDisputes(ctx context.Context, uid string) ([]Dispute, error)
+	ListDisputes(ctx context.Context, status string) ([]Dispute, error)
+	ResolveDispute(ctx context.Context, adminUID, id string, res DisputeResolution) (Dispute, error)
+}
+
+type PromoApper interface {
+	CreatePromoCode(ctx context.Context, adminUID string, p PromoCode) (PromoCode, error)
+	ListPromoCodes(ctx context.Context) ([]PromoCode, error)
+	RevokePromoCode(ctx context.Context, id string) error
+	RedeemPromoCode(ctx context.Context, uid, code string) (PromoRedemption, error)
+}
+
+type HouseholdApper interface {
+	CreateHousehold(ctx context.Context, uid string, req HouseholdRequest) (Household, error)
+	GetHousehold(ctx context.Context, uid string) (Household, error)
+	DissolveHousehold(ctx context.Context, uid string) error
+	InviteMember(ctx context.Context, uid string, req HouseholdInviteRequest) (HouseholdInvite, error)
+	ListInvites(ctx context.Context, uid string) ([]HouseholdInvite, error)
+	AcceptInvite(ctx context.Context, uid, id string) (Household, error)
+	DeclineInvite(ctx context.Context, uid, id string) error
+	RemoveMember(ctx context.Context, uid, memberUID string) error
+	SetSpendLimit(ctx context.Context, uid, memberUID string, limit *float32) error
+}
+
+type TierApper interface {
+	GetTier(ctx context.Context, uid string) (TierStatus, error)
+	RecalculateTiers(ctx context.Context) (int, error)
+	RecalculateTiersIfDue(ctx context.Context) (int, bool, error)
+}
+
+type MerchantApper interface {
+	CreateOrder(ctx context.Context, order MerchantOrder) error
+	RefundOrder(ctx context.Context, merchantID, number string, req RefundRequest) (Refund, error)
 }
 
 type WithdrawalApper interface {
@@ -86,3 +897,13 @@ type WithdrawalApper interface {
 }
 
 type UIDKey struct{}
+
+type ClientKey struct{}
+
+type SIDKey struct{}
+
+type RoleKey struct{}
+
+type PartnerKey struct{}
+
+type MerchantKey struct{}
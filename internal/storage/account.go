@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"context"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+func (user *User) ExportUserData(ctx context.Context, uid string) (sharedTypes.UserData, error) {
+	data := sharedTypes.UserData{
+		Orders:      []sharedTypes.Order{},
+		Withdrawals: []sharedTypes.Withdrawal{},
+		Adjustments: []sharedTypes.Adjustment{},
+		Transfers:   []sharedTypes.Transfer{},
+		Sessions:    []sharedTypes.Session{},
+		LoginEvents: []sharedTypes.LoginEvent{},
+	}
+
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+
+	err := pgx.BeginTxFunc(ctx, user.Conn, txOptions, func(tx pgx.Tx) error {
+		sqlProfile := `
+		SELECT uid, login, created_at::timestamptz, current_balance, withdrawn, totp_enabled, COALESCE(card_id, '') FROM USERS
+		WHERE uid = $1
+		`
+
+		p := &data.Profile
+		err := tx.QueryRow(ctx, sqlProfile, uid).Scan(&p.UID, &p.Login, &p.CreatedAt, &p.Balance.Current, &p.Balance.Withdrawn, &p.TwoFactorEnabled, &p.CardID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `SELECT ID, status, accrual, uploaded_at::timestamptz FROM orders WHERE UID = $1 ORDER BY uploaded_at`, uid)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			entry := sharedTypes.Order{}
+			err = rows.Scan(&entry.Number, &entry.Status, &entry.Accrual, &entry.UploadedAt)
+
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			data.Orders = append(data.Orders, entry)
+		}
+
+		rows.Close()
+
+		rows, err = tx.Query(ctx, `SELECT id, sum, processed_at::timestamptz FROM withdrawals WHERE UID = $1 ORDER BY processed_at`, uid)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			entry := sharedTypes.Withdrawal{}
+			err = rows.Scan(&entry.ID, &entry.Sum, &entry.ProcessedAt)
+
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			data.Withdrawals = append(data.Withdrawals, entry)
+		}
+
+		rows.Close()
+
+		rows, err = tx.Query(ctx, `SELECT id, amount, reason, comment, created_at::timestamptz FROM BALANCE_ADJUSTMENTS WHERE uid = $1 ORDER BY created_at`, uid)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			entry := sharedTypes.Adjustment{}
+			err = rows.Scan(&entry.ID, &entry.Amount, &entry.Reason, &entry.Comment, &entry.CreatedAt)
+
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			data.Adjustments = append(data.Adjustments, entry)
+		}
+
+		rows.Close()
+
+		data.Transfers, err = listTransfers(ctx, tx, uid)
+		if err != nil {
+			return err
+		}
+
+		sqlSessions := `
+		SELECT id, COALESCE(ip, ''), COALESCE(user_agent, ''), created_at::timestamptz, last_seen_at::timestamptz, revoked_at::timestamptz FROM SESSIONS
+		WHERE uid = $1 ORDER BY created_at
+		`
+
+		rows, err = tx.Query(ctx, sqlSessions, uid)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			entry := sharedTypes.Session{}
+			err = rows.Scan(&entry.ID, &entry.IP, &entry.UserAgent, &entry.CreatedAt, &entry.LastSeenAt, &entry.RevokedAt)
+
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			data.Sessions = append(data.Sessions, entry)
+		}
+
+		rows.Close()
+
+		sqlLoginEvents := `
+		SELECT login, ip, COALESCE(user_agent, ''), outcome, created_at::timestamptz FROM LOGIN_EVENTS
+		WHERE uid = $1 OR login = $2 ORDER BY created_at
+		`
+
+		rows, err = tx.Query(ctx, sqlLoginEvents, uid, p.Login)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			entry := sharedTypes.LoginEvent{}
+			err = rows.Scan(&entry.Login, &entry.IP, &entry.UserAgent, &entry.Outcome, &entry.CreatedAt)
+
+			if err != nil {
+				return err
+			}
+
+			data.LoginEvents = append(data.LoginEvents, entry)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return sharedTypes.UserData{}, err
+	}
+
+	return data, nil
+}
+
+// DeleteUser anonymises the account: financial records are re-keyed to a fresh
+// user row without login or password, everything personal is removed.
+func (user *User) DeleteUser(ctx context.Context, uid string) (string, error) {
+	var anonUID string
+
+	err := pgx.BeginFunc(ctx, user.Conn, func(tx pgx.Tx) error {
+		var login string
+
+		var owner bool
+
+		sqlUser := `
+		SELECT login, EXISTS (SELECT 1 FROM HOUSEHOLDS WHERE owner_uid = USERS.uid) FROM USERS
+		WHERE uid = $1
+		FOR UPDATE
+		`
+
+		err := tx.QueryRow(ctx, sqlUser, uid).Scan(&login, &owner)
+		if err != nil {
+			return err
+		}
+
+		if owner {
+			return utils.ErrHouseholdOwner
+		}
+
+		sqlAnon := `
+		INSERT INTO USERS (login, password_hash, current_balance, withdrawn, created_at, deleted_at)
+		SELECT NULL, NULL, current_balance, withdrawn, created_at, current_timestamp FROM USERS
+		WHERE uid = $1
+		RETURNING uid;
+		`
+
+		err = tx.QueryRow(ctx, sqlAnon, uid).Scan(&anonUID)
+		if err != nil {
+			return err
+		}
+
+		statements := []string{
+			`UPDATE ORDERS SET uid = $2 WHERE uid = $1`,
+			`UPDATE ORDER_HISTORY SET uid = $2 WHERE uid = $1`,
+			`UPDATE ORDER_HISTORY SET previous_uid = $2 WHERE previous_uid = $1`,
+			`UPDATE ORDER_DISPUTES SET claimant_uid = $2 WHERE claimant_uid = $1`,
+			`UPDATE ORDER_DISPUTES SET holder_uid = $2 WHERE holder_uid = $1`,
+			`UPDATE WITHDRAWALS SET uid = $2 WHERE uid = $1`,
+			`UPDATE BALANCE_ADJUSTMENTS SET uid = $2 WHERE uid = $1`,
+			`UPDATE ACCRUAL_LOTS SET uid = $2 WHERE uid = $1`,
+			`UPDATE CAMPAIGN_REWARDS SET uid = $2 WHERE uid = $1`,
+			`UPDATE PROMO_REDEMPTIONS SET uid = $2 WHERE uid = $1`,
+			`UPDATE REFERRALS SET referrer_uid = $2 WHERE referrer_uid = $1`,
+			`UPDATE REFERRALS SET referred_uid = $2 WHERE referred_uid = $1`,
+			`UPDATE POINT_TRANSFERS SET sender_uid = $2 WHERE sender_uid = $1`,
+			`UPDATE POINT_TRANSFERS SET recipient_uid = $2 WHERE recipient_uid = $1`,
+			`UPDATE USER_MERGES SET source_uid = $2 WHERE source_uid = $1`,
+			`UPDATE USER_MERGES SET target_uid = $2 WHERE target_uid = $1`,
+			`UPDATE USERS SET merged_into = $2 WHERE merged_into = $1`,
+		}
+
+		for _, sql := range statements {
+			_, err = tx.Exec(ctx, sql, uid, anonUID)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM HOUSEHOLD_MEMBERS WHERE uid = $1`, uid)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM HOUSEHOLD_INVITES WHERE uid = $1`, uid)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM LOGIN_EVENTS WHERE uid = $1 OR login = $2`, uid, login)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM USERS WHERE uid = $1`, uid)
+
+		return err
+	})
+
+	return anonUID, err
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Adjustment struct {
+	Conn *pgxpool.Pool
+}
+
+func InitAdjustment(conn *pgxpool.Pool) (*Adjustment, error) {
+	return &Adjustment{conn}, nil
+}
+
+func (a *Adjustment) CreateAdjustment(ctx context.Context, adj sharedTypes.Adjustment) (sharedTypes.Adjustment, error) {
+	err := pgx.BeginFunc(ctx, a.Conn, func(tx pgx.Tx) error {
+		sqlUpdateUser := `
+		UPDATE USERS SET current_balance = current_balance + $1
+		WHERE uid = $2 AND deleted_at IS NULL
+		RETURNING current_balance
+		`
+
+		var balance float32
+		err := tx.QueryRow(ctx, sqlUpdateUser, adj.Amount, adj.UID).Scan(&balance)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if balance < 0 {
+			return utils.ErrPaymentError
+		}
+
+		sqlInsert := `
+		INSERT INTO BALANCE_ADJUSTMENTS (uid, admin_uid, amount, reason, comment)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at::timestamptz
+		`
+
+		err = tx.QueryRow(ctx, sqlInsert, adj.UID, adj.AdminUID, adj.Amount, adj.Reason, adj.Comment).Scan(&adj.ID, &adj.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		if adj.Amount < 0 {
+			return consumeLots(ctx, tx, adj.UID, -adj.Amount)
+		}
+
+		return addLot(ctx, tx, adj.UID, lotSourceAdjustment, adj.ID, adj.Amount)
+	})
+
+	return adj, err
+}
+
+func (a *Adjustment) ListAdjustments(ctx context.Context, uid string) ([]sharedTypes.Adjustment, error) {
+	sqlStatement := `
+	SELECT id, amount, reason, comment, created_at::timestamptz FROM BALANCE_ADJUSTMENTS WHERE uid = $1 ORDER BY created_at
+	`
+
+	rows, err := a.Conn.Query(ctx, sqlStatement, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	adjustments := []sharedTypes.Adjustment{}
+
+	for rows.Next() {
+		entry := sharedTypes.Adjustment{}
+		err = rows.Scan(&entry.ID, &entry.Amount, &entry.Reason, &entry.Comment, &entry.CreatedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		adjustments = append(adjustments, entry)
+	}
+
+	return adjustments, rows.Err()
+}
@@ -0,0 +1,362 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Admin struct {
+	Conn *pgxpool.Pool
+}
+
+func InitAdmin(conn *pgxpool.Pool) (*Admin, error) {
+	return &Admin{conn}, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (a *Admin) SearchUsers(ctx context.Context, login string, limit int) ([]sharedTypes.UserSummary, error) {
+	sqlStatement := `
+	SELECT uid, login, role, current_balance, withdrawn, created_at::timestamptz FROM USERS
+	WHERE lower(login) LIKE lower($1) || '%'
+	ORDER BY login
+	LIMIT $2
+	`
+
+	rows, err := a.Conn.Query(ctx, sqlStatement, likeEscaper.Replace(login), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := []sharedTypes.UserSummary{}
+
+	for rows.Next() {
+		entry := sharedTypes.UserSummary{}
+		err = rows.Scan(&entry.UID, &entry.Login, &entry.Role, &entry.Balance.Current, &entry.Balance.Withdrawn, &entry.CreatedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, entry)
+	}
+
+	return list, rows.Err()
+}
+
+func (a *Admin) GetUserDetail(ctx context.Context, uid string, orderLimit int) (sharedTypes.UserDetail, error) {
+	sqlUser := `
+	SELECT uid, COALESCE(login, ''), role, current_balance, withdrawn, created_at::timestamptz, totp_enabled,
+	suspended_at IS NOT NULL, frozen_at IS NOT NULL, COALESCE(card_id, '') FROM USERS
+	WHERE uid = $1
+	`
+
+	d := sharedTypes.UserDetail{RecentOrders: []sharedTypes.Order{}}
+	err := a.Conn.QueryRow(ctx, sqlUser, uid).Scan(&d.UID, &d.Login, &d.Role, &d.Balance.Current, &d.Balance.Withdrawn, &d.CreatedAt, &d.TwoFactorEnabled,
+		&d.Suspended, &d.Frozen, &d.CardID)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return d, utils.ErrNotFound
+	}
+
+	if err != nil {
+		return d, err
+	}
+
+	sqlOrders := `
+	SELECT ID, status, accrual, uploaded_at::timestamptz FROM orders WHERE UID = $1
+	ORDER BY uploaded_at DESC
+	LIMIT $2
+	`
+
+	rows, err := a.Conn.Query(ctx, sqlOrders, uid, orderLimit)
+	if err != nil {
+		return d, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := sharedTypes.Order{}
+		err = rows.Scan(&entry.Number, &entry.Status, &entry.Accrual, &entry.UploadedAt)
+
+		if err != nil {
+			return d, err
+		}
+
+		d.RecentOrders = append(d.RecentOrders, entry)
+	}
+
+	return d, rows.Err()
+}
+
+func (a *Admin) GetOrder(ctx context.Context, number string) (sharedTypes.OrderOwner, error) {
+	sqlStatement := `
+	SELECT o.id, o.status, o.accrual, o.uploaded_at::timestamptz, o.uid, COALESCE(u.login, '') FROM ORDERS o
+	JOIN USERS u ON u.uid = o.uid
+	WHERE o.id = $1
+	`
+
+	o := sharedTypes.OrderOwner{History: []sharedTypes.OrderEvent{}}
+	err := a.Conn.QueryRow(ctx, sqlStatement, number).Scan(&o.Number, &o.Status, &o.Accrual, &o.UploadedAt, &o.UID, &o.Login)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return o, utils.ErrNotFound
+	}
+
+	if err != nil {
+		return o, err
+	}
+
+	sqlHistory := `
+	SELECT event, status, uid, COALESCE(previous_uid::text, ''), created_at::timestamptz FROM ORDER_HISTORY
+	WHERE order_id = $1
+	ORDER BY id
+	`
+
+	rows, err := a.Conn.Query(ctx, sqlHistory, number)
+	if err != nil {
+		return o, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := sharedTypes.OrderEvent{}
+		err = rows.Scan(&entry.Event, &entry.Status, &entry.UID, &entry.PreviousUID, &entry.CreatedAt)
+
+		if err != nil {
+			return o, err
+		}
+
+		o.History = append(o.History, entry)
+	}
+
+	return o, rows.Err()
+}
+
+// SetSuspended also revokes every open session, so the account cannot be used
+// again until it is reinstated and the owner logs in.
+func (a *Admin) SetSuspended(ctx context.Context, uid string, suspended bool) (bool, error) {
+	found := false
+
+	err := pgx.BeginFunc(ctx, a.Conn, func(tx pgx.Tx) error {
+		sqlUpdate := `
+		UPDATE USERS SET suspended_at = CASE WHEN $2 THEN COALESCE(suspended_at, current_timestamp) END
+		WHERE uid = $1 AND deleted_at IS NULL
+		`
+
+		tag, err := tx.Exec(ctx, sqlUpdate, uid, suspended)
+		if err != nil {
+			return err
+		}
+
+		found = tag.RowsAffected() == 1
+
+		if !found || !suspended {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE SESSIONS SET revoked_at = current_timestamp WHERE uid = $1 AND revoked_at IS NULL`, uid)
+
+		return err
+	})
+
+	return found, err
+}
+
+func (a *Admin) SetFrozen(ctx context.Context, uid string, frozen bool) (bool, error) {
+	sqlUpdate := `
+	UPDATE USERS SET frozen_at = CASE WHEN $2 THEN COALESCE(frozen_at, current_timestamp) END
+	WHERE uid = $1 AND deleted_at IS NULL
+	`
+
+	tag, err := a.Conn.Exec(ctx, sqlUpdate, uid, frozen)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// SetCardID links a loyalty card to the user; an empty card unlinks it.
+func (a *Admin) SetCardID(ctx context.Context, uid, cardID string) (bool, error) {
+	sqlUpdate := `
+	UPDATE USERS SET card_id = NULLIF($2, '')
+	WHERE uid = $1 AND deleted_at IS NULL
+	`
+
+	tag, err := a.Conn.Exec(ctx, sqlUpdate, uid, cardID)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return false, utils.ErrDuplicate
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// MergeUsers re-keys the source's records to the target before touching the
+// balances, so both foreign keys keep pointing at a live users row.
+func (a *Admin) MergeUsers(ctx context.Context, merge sharedTypes.UserMerge) (sharedTypes.UserMerge, error) {
+	err := pgx.BeginFunc(ctx, a.Conn, func(tx pgx.Tx) error {
+		sqlLock := `
+		SELECT uid, current_balance, withdrawn, deleted_at IS NULL AND merged_into IS NULL, card_id FROM USERS
+		WHERE uid IN ($1, $2)
+		ORDER BY uid
+		FOR UPDATE
+		`
+
+		rows, err := tx.Query(ctx, sqlLock, merge.SourceUID, merge.TargetUID)
+		if err != nil {
+			return err
+		}
+
+		found := 0
+
+		var sourceCard *string
+
+		for rows.Next() {
+			var (
+				uid                string
+				current, withdrawn float32
+				active             bool
+				cardID             *string
+			)
+
+			err = rows.Scan(&uid, &current, &withdrawn, &active, &cardID)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			if !active {
+				rows.Close()
+				return utils.ErrMerged
+			}
+
+			if uid == merge.SourceUID {
+				merge.MovedBalance = current
+				merge.MovedWithdrawn = withdrawn
+				sourceCard = cardID
+			}
+
+			found++
+		}
+
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		if found != 2 {
+			return utils.ErrNotFound
+		}
+
+		tag, err := tx.Exec(ctx, `UPDATE ORDERS SET uid = $2 WHERE uid = $1`, merge.SourceUID, merge.TargetUID)
+		if err != nil {
+			return err
+		}
+
+		merge.MovedOrders = int(tag.RowsAffected())
+
+		for _, sql := range []string{
+			`UPDATE ORDER_HISTORY SET uid = $2 WHERE uid = $1`,
+			`UPDATE ORDER_HISTORY SET previous_uid = $2 WHERE previous_uid = $1`,
+			`UPDATE ORDER_DISPUTES SET claimant_uid = $2 WHERE claimant_uid = $1`,
+			`UPDATE ORDER_DISPUTES SET holder_uid = $2 WHERE holder_uid = $1`,
+			`UPDATE ACCRUAL_LOTS SET uid = $2 WHERE uid = $1`,
+			`UPDATE CAMPAIGN_REWARDS SET uid = $2 WHERE uid = $1`,
+			`UPDATE PROMO_REDEMPTIONS SET uid = $2 WHERE uid = $1`,
+			`UPDATE POINT_TRANSFERS SET sender_uid = $2 WHERE sender_uid = $1`,
+			`UPDATE POINT_TRANSFERS SET recipient_uid = $2 WHERE recipient_uid = $1`,
+			`UPDATE REFERRALS SET referrer_uid = $2 WHERE referrer_uid = $1`,
+			// the target keeps the referral it signed up with, if any
+			`UPDATE REFERRALS SET referred_uid = $2 WHERE referred_uid = $1
+			AND NOT EXISTS (SELECT 1 FROM REFERRALS WHERE referred_uid = $2)`,
+			// an account cannot earn a reward for inviting itself
+			`UPDATE REFERRALS SET status = 'rejected', reason = 'merged', closed_at = current_timestamp
+			WHERE referrer_uid = referred_uid AND referred_uid IN ($1, $2) AND status = 'pending'`,
+		} {
+			_, err = tx.Exec(ctx, sql, merge.SourceUID, merge.TargetUID)
+			if err != nil {
+				return err
+			}
+		}
+
+		tag, err = tx.Exec(ctx, `UPDATE WITHDRAWALS SET uid = $2 WHERE uid = $1`, merge.SourceUID, merge.TargetUID)
+		if err != nil {
+			return err
+		}
+
+		merge.MovedWithdrawals = int(tag.RowsAffected())
+
+		_, err = tx.Exec(ctx, `UPDATE BALANCE_ADJUSTMENTS SET uid = $2 WHERE uid = $1`, merge.SourceUID, merge.TargetUID)
+		if err != nil {
+			return err
+		}
+
+		sqlTarget := `
+		UPDATE USERS SET current_balance = current_balance + $2, withdrawn = withdrawn + $3
+		WHERE uid = $1
+		`
+
+		_, err = tx.Exec(ctx, sqlTarget, merge.TargetUID, merge.MovedBalance, merge.MovedWithdrawn)
+		if err != nil {
+			return err
+		}
+
+		sqlSource := `
+		UPDATE USERS SET current_balance = 0, withdrawn = 0, merged_into = $2, card_id = NULL,
+		suspended_at = COALESCE(suspended_at, current_timestamp)
+		WHERE uid = $1
+		`
+
+		_, err = tx.Exec(ctx, sqlSource, merge.SourceUID, merge.TargetUID)
+		if err != nil {
+			return err
+		}
+
+		err = mergeHouseholds(ctx, tx, merge.SourceUID, merge.TargetUID)
+		if err != nil {
+			return err
+		}
+
+		// the target keeps its own card, otherwise it inherits the source's
+		_, err = tx.Exec(ctx, `UPDATE USERS SET card_id = COALESCE(card_id, $2) WHERE uid = $1`, merge.TargetUID, sourceCard)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE SESSIONS SET revoked_at = current_timestamp WHERE uid = $1 AND revoked_at IS NULL`, merge.SourceUID)
+		if err != nil {
+			return err
+		}
+
+		sqlRecord := `
+		INSERT INTO USER_MERGES (source_uid, target_uid, admin_uid, moved_orders, moved_withdrawals, moved_balance, moved_withdrawn)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at::timestamptz
+		`
+
+		return tx.QueryRow(ctx, sqlRecord, merge.SourceUID, merge.TargetUID, merge.AdminUID, merge.MovedOrders,
+			merge.MovedWithdrawals, merge.MovedBalance, merge.MovedWithdrawn).Scan(&merge.ID, &merge.CreatedAt)
+	})
+
+	return merge, err
+}
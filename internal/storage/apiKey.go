@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type APIKey struct {
+	Conn *pgxpool.Pool
+}
+
+func InitAPIKey(conn *pgxpool.Pool) (*APIKey, error) {
+	return &APIKey{conn}, nil
+}
+
+func (k *APIKey) CreateAPIKey(ctx context.Context, key sharedTypes.APIKey) (sharedTypes.APIKey, error) {
+	sqlStatement := `
+	INSERT INTO API_KEYS (name, prefix, key_hash, scopes, rate_limit, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at::timestamptz
+	`
+
+	err := k.Conn.QueryRow(ctx, sqlStatement, key.Name, key.Prefix, key.Hash, key.Scopes, key.RateLimit, key.CreatedBy).Scan(&key.ID, &key.CreatedAt)
+
+	return key, err
+}
+
+func (k *APIKey) ListAPIKeys(ctx context.Context) ([]sharedTypes.APIKey, error) {
+	sqlStatement := `
+	SELECT id, name, prefix, scopes, rate_limit, created_at::timestamptz, last_used_at::timestamptz, revoked_at::timestamptz FROM API_KEYS
+	ORDER BY id
+	`
+
+	rows, err := k.Conn.Query(ctx, sqlStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := []sharedTypes.APIKey{}
+
+	for rows.Next() {
+		entry := sharedTypes.APIKey{}
+		err = rows.Scan(&entry.ID, &entry.Name, &entry.Prefix, &entry.Scopes, &entry.RateLimit, &entry.CreatedAt, &entry.LastUsedAt, &entry.RevokedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, entry)
+	}
+
+	return keys, rows.Err()
+}
+
+func (k *APIKey) RevokeAPIKey(ctx context.Context, id string) (bool, error) {
+	sqlStatement := `
+	UPDATE API_KEYS SET revoked_at = current_timestamp
+	WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	tag, err := k.Conn.Exec(ctx, sqlStatement, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetAPIKey only returns keys that have not been revoked.
+func (k *APIKey) GetAPIKey(ctx context.Context, prefix string) (sharedTypes.APIKey, error) {
+	sqlStatement := `
+	SELECT id, name, prefix, key_hash, scopes, rate_limit, created_at::timestamptz FROM API_KEYS
+	WHERE prefix = $1 AND revoked_at IS NULL
+	`
+
+	var key sharedTypes.APIKey
+	err := k.Conn.QueryRow(ctx, sqlStatement, prefix).Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &key.Scopes, &key.RateLimit, &key.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return key, utils.ErrNotFound
+	}
+
+	return key, err
+}
+
+// HitRateLimit counts a request against the key's fixed window and returns the
+// count so far together with the seconds left until the window resets.
+func (k *APIKey) HitRateLimit(ctx context.Context, id string, window uint) (int, int, error) {
+	sqlStatement := `
+	WITH w AS (
+		SELECT to_timestamp((floor(extract(epoch FROM current_timestamp) / $2::integer) * $2::integer)::double precision) AS start
+	)
+	UPDATE API_KEYS SET
+		window_count = CASE WHEN window_start = w.start THEN window_count + 1 ELSE 1 END,
+		window_start = w.start,
+		last_used_at = current_timestamp
+	FROM w
+	WHERE id = $1
+	RETURNING window_count, ceil(extract(epoch FROM w.start - current_timestamp) + $2::integer)::integer
+	`
+
+	var count, reset int
+	err := k.Conn.QueryRow(ctx, sqlStatement, id, window).Scan(&count, &reset)
+
+	return count, reset, err
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Audit struct {
+	Conn *pgxpool.Pool
+}
+
+func InitAudit(conn *pgxpool.Pool) (*Audit, error) {
+	return &Audit{conn}, nil
+}
+
+// AppendAudit serialises writers with an advisory lock so every entry is
+// chained to the one inserted right before it.
+func (a *Audit) AppendAudit(ctx context.Context, entry sharedTypes.AuditEntry) error {
+	return pgx.BeginFunc(ctx, a.Conn, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('audit_log'))`)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, `SELECT hash FROM AUDIT_LOG ORDER BY id DESC LIMIT 1`).Scan(&entry.PrevHash)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			entry.PrevHash = ""
+		} else if err != nil {
+			return err
+		}
+
+		entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		entry.Hash = utils.AuditHash(entry)
+
+		sqlInsert := `
+		INSERT INTO AUDIT_LOG (actor, action, target, request_id, ip, before, after, created_at, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`
+
+		_, err = tx.Exec(ctx, sqlInsert, entry.Actor, entry.Action, entry.Target, entry.RequestID, entry.IP,
+			rawToText(entry.Before), rawToText(entry.After), entry.CreatedAt, entry.PrevHash, entry.Hash)
+
+		return err
+	})
+}
+
+func (a *Audit) ListAudit(ctx context.Context, filter sharedTypes.AuditFilter) ([]sharedTypes.AuditEntry, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	addCondition := func(column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s $%d", column, len(args)))
+	}
+
+	if filter.Actor != "" {
+		addCondition("actor =", filter.Actor)
+	}
+
+	if filter.Action != "" {
+		addCondition("action =", filter.Action)
+	}
+
+	if filter.Target != "" {
+		addCondition("target =", filter.Target)
+	}
+
+	if filter.BeforeID > 0 {
+		addCondition("id <", filter.BeforeID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	sqlStatement := fmt.Sprintf(`
+	SELECT %s FROM AUDIT_LOG
+	%s
+	ORDER BY id DESC
+	LIMIT $%d
+	`, auditColumns, where, len(args))
+
+	return a.queryAudit(ctx, sqlStatement, args...)
+}
+
+func (a *Audit) ListAuditChain(ctx context.Context, afterID int64, limit int) ([]sharedTypes.AuditEntry, error) {
+	sqlStatement := `
+	SELECT ` + auditColumns + ` FROM AUDIT_LOG
+	WHERE id > $1
+	ORDER BY id
+	LIMIT $2
+	`
+
+	return a.queryAudit(ctx, sqlStatement, afterID, limit)
+}
+
+const auditColumns = `id, actor, action, target, COALESCE(request_id, ''), COALESCE(ip, ''), before::text, after::text, created_at, prev_hash, hash`
+
+func (a *Audit) queryAudit(ctx context.Context, sqlStatement string, args ...interface{}) ([]sharedTypes.AuditEntry, error) {
+	rows, err := a.Conn.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := []sharedTypes.AuditEntry{}
+
+	for rows.Next() {
+		var before, after *string
+
+		entry := sharedTypes.AuditEntry{}
+		err = rows.Scan(&entry.ID, &entry.Actor, &entry.Action, &entry.Target, &entry.RequestID, &entry.IP,
+			&before, &after, &entry.CreatedAt, &entry.PrevHash, &entry.Hash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Before = textToRaw(before)
+		entry.After = textToRaw(after)
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func rawToText(raw json.RawMessage) *string {
+	if raw == nil {
+		return nil
+	}
+
+	text := string(raw)
+
+	return &text
+}
+
+func textToRaw(text *string) json.RawMessage {
+	if text == nil {
+		return nil
+	}
+
+	return json.RawMessage(*text)
+}
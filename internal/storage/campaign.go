@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const campaignColumns = `
+	c.id, c.name, c.starts_at, c.ends_at, c.first_order, COALESCE(c.tier, ''), c.min_accrual,
+	c.reward_type, c.reward_value, c.user_cap, c.created_at::timestamptz
+`
+
+type Campaign struct {
+	Conn *pgxpool.Pool
+}
+
+func InitCampaign(conn *pgxpool.Pool) (*Campaign, error) {
+	return &Campaign{conn}, nil
+}
+
+func scanCampaign(row pgx.Row, c *sharedTypes.Campaign, extra ...interface{}) error {
+	dest := []interface{}{&c.ID, &c.Name, &c.StartsAt, &c.EndsAt, &c.FirstOrder, &c.Tier, &c.MinAccrual, &c.RewardType, &c.RewardValue, &c.UserCap, &c.CreatedAt}
+
+	return row.Scan(append(dest, extra...)...)
+}
+
+// CreateCampaign reports an unknown tier as ErrWrongFormat.
+func (cs *Campaign) CreateCampaign(ctx context.Context, c sharedTypes.Campaign) (sharedTypes.Campaign, error) {
+	sqlStatement := `
+	INSERT INTO CAMPAIGNS (name, starts_at, ends_at, first_order, tier, min_accrual, reward_type, reward_value, user_cap, created_by)
+	VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10)
+	RETURNING id, created_at::timestamptz
+	`
+
+	err := cs.Conn.QueryRow(ctx, sqlStatement, c.Name, c.StartsAt, c.EndsAt, c.FirstOrder, c.Tier, c.MinAccrual, c.RewardType, c.RewardValue, c.UserCap, c.CreatedBy).
+		Scan(&c.ID, &c.CreatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return c, utils.ErrWrongFormat
+	}
+
+	return c, err
+}
+
+func (cs *Campaign) ListCampaigns(ctx context.Context) ([]sharedTypes.Campaign, error) {
+	sqlStatement := `
+	SELECT ` + campaignColumns + `, COALESCE(SUM(r.amount), 0) FROM CAMPAIGNS c
+	LEFT JOIN CAMPAIGN_REWARDS r ON r.campaign_id = c.id
+	GROUP BY c.id
+	ORDER BY c.starts_at DESC, c.id DESC
+	`
+
+	return cs.queryCampaigns(ctx, sqlStatement, func(c *sharedTypes.Campaign) *float32 { return &c.Rewarded })
+}
+
+// ListActiveCampaigns returns the campaigns running right now together with
+// what the user has already been granted by each of them.
+func (cs *Campaign) ListActiveCampaigns(ctx context.Context, uid string) ([]sharedTypes.Campaign, error) {
+	sqlStatement := `
+	SELECT ` + campaignColumns + `, COALESCE(SUM(r.amount), 0) FROM CAMPAIGNS c
+	LEFT JOIN CAMPAIGN_REWARDS r ON r.campaign_id = c.id AND r.uid = $1
+	WHERE c.starts_at <= current_timestamp AND c.ends_at > current_timestamp
+	GROUP BY c.id
+	ORDER BY c.id
+	`
+
+	return cs.queryCampaigns(ctx, sqlStatement, func(c *sharedTypes.Campaign) *float32 { return &c.Granted }, uid)
+}
+
+func (cs *Campaign) queryCampaigns(ctx context.Context, sqlStatement string, total func(*sharedTypes.Campaign) *float32, args ...interface{}) ([]sharedTypes.Campaign, error) {
+	rows, err := cs.Conn.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	campaigns := []sharedTypes.Campaign{}
+
+	for rows.Next() {
+		entry := sharedTypes.Campaign{}
+		err = scanCampaign(rows, &entry, total(&entry))
+
+		if err != nil {
+			return nil, err
+		}
+
+		campaigns = append(campaigns, entry)
+	}
+
+	return campaigns, rows.Err()
+}
+
+// EndCampaign stops a campaign that has not ended yet.
+func (cs *Campaign) EndCampaign(ctx context.Context, id string) (bool, error) {
+	sqlStatement := `
+	UPDATE CAMPAIGNS SET ends_at = current_timestamp
+	WHERE id = $1 AND ends_at > current_timestamp
+	`
+
+	tag, err := cs.Conn.Exec(ctx, sqlStatement, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetRewardSubject tells the campaigns the user's tier and whether the order
+// is their first processed one.
+func (cs *Campaign) GetRewardSubject(ctx context.Context, uid, orderID string) (sharedTypes.RewardSubject, error) {
+	sqlStatement := `
+	SELECT u.tier, NOT EXISTS (
+		SELECT 1 FROM ORDERS o WHERE o.uid = u.uid AND o.status = 'PROCESSED' AND o.id <> $2
+	) FROM USERS u
+	WHERE u.uid = $1
+	`
+
+	var subject sharedTypes.RewardSubject
+	err := cs.Conn.QueryRow(ctx, sqlStatement, uid, orderID).Scan(&subject.Tier, &subject.FirstOrder)
+
+	return subject, err
+}
+
+// GrantReward credits a campaign bonus as its own entry. An order is rewarded
+// at most once per campaign, so a repeated grant reports false. The user row
+// is locked and the user's rewards summed again before the insert, so grants
+// running on other instances cannot take the user past a non-zero cap.
+func (cs *Campaign) GrantReward(ctx context.Context, reward sharedTypes.CampaignReward, userCap float32) (sharedTypes.CampaignReward, bool, error) {
+	granted := false
+
+	err := pgx.BeginFunc(ctx, cs.Conn, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `SELECT uid FROM USERS WHERE uid = $1 FOR UPDATE`, reward.UID)
+		if err != nil {
+			return err
+		}
+
+		if userCap > 0 {
+			sqlGranted := `SELECT COALESCE(SUM(amount), 0) FROM CAMPAIGN_REWARDS WHERE campaign_id = $1 AND uid = $2`
+
+			var total float32
+
+			err = tx.QueryRow(ctx, sqlGranted, reward.CampaignID, reward.UID).Scan(&total)
+			if err != nil {
+				return err
+			}
+
+			if reward.Amount > userCap-total {
+				reward.Amount = userCap - total
+			}
+
+			if reward.Amount <= 0 {
+				return nil
+			}
+		}
+
+		sqlInsert := `
+		INSERT INTO CAMPAIGN_REWARDS (campaign_id, uid, order_id, amount)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (campaign_id, order_id) DO NOTHING
+		RETURNING id, created_at::timestamptz
+		`
+
+		err = tx.QueryRow(ctx, sqlInsert, reward.CampaignID, reward.UID, reward.OrderID, reward.Amount).Scan(&reward.ID, &reward.CreatedAt)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance + $2 WHERE uid = $1`, reward.UID, reward.Amount)
+		if err != nil {
+			return err
+		}
+
+		granted = true
+
+		return addLot(ctx, tx, reward.UID, lotSourceCampaign, reward.ID, reward.Amount)
+	})
+
+	return reward, granted, err
+}
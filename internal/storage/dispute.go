@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Dispute struct {
+	Conn *pgxpool.Pool
+}
+
+func InitDispute(conn *pgxpool.Pool) (*Dispute, error) {
+	return &Dispute{conn}, nil
+}
+
+const disputeColumns = `id, order_id, claimant_uid, holder_uid, evidence, status, COALESCE(resolution, ''), created_at::timestamptz, resolved_at::timestamptz`
+
+func scanDispute(row pgx.Row, d *sharedTypes.Dispute) error {
+	return row.Scan(&d.ID, &d.Number, &d.ClaimantUID, &d.HolderUID, &d.Evidence, &d.Status, &d.Resolution, &d.CreatedAt, &d.ResolvedAt)
+}
+
+// CreateDispute records the current owner of the order as the holder, so a
+// later change of ownership can be detected when the dispute is resolved.
+func (ds *Dispute) CreateDispute(ctx context.Context, d sharedTypes.Dispute) (sharedTypes.Dispute, error) {
+	err := ds.Conn.QueryRow(ctx, `SELECT uid FROM orders WHERE id = $1`, d.Number).Scan(&d.HolderUID)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return d, utils.ErrNotFound
+	}
+
+	if err != nil {
+		return d, err
+	}
+
+	if d.HolderUID == d.ClaimantUID {
+		return d, utils.ErrWrongFormat
+	}
+
+	sqlStatement := `
+	INSERT INTO ORDER_DISPUTES (order_id, claimant_uid, holder_uid, evidence)
+	VALUES ($1, $2, $3, $4)
+	RETURNING ` + disputeColumns
+
+	err = scanDispute(ds.Conn.QueryRow(ctx, sqlStatement, d.Number, d.ClaimantUID, d.HolderUID, d.Evidence), &d)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return d, utils.ErrDuplicate
+	}
+
+	return d, err
+}
+
+func (ds *Dispute) GetDispute(ctx context.Context, id string) (sharedTypes.Dispute, error) {
+	var d sharedTypes.Dispute
+
+	err := scanDispute(ds.Conn.QueryRow(ctx, `SELECT `+disputeColumns+` FROM ORDER_DISPUTES WHERE id = $1`, id), &d)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return d, utils.ErrNotFound
+	}
+
+	return d, err
+}
+
+func (ds *Dispute) ListUserDisputes(ctx context.Context, uid string) ([]sharedTypes.Dispute, error) {
+	sqlStatement := `
+	SELECT ` + disputeColumns + ` FROM ORDER_DISPUTES
+	WHERE claimant_uid = $1 OR holder_uid = $1
+	ORDER BY id DESC
+	`
+
+	return ds.queryDisputes(ctx, sqlStatement, uid)
+}
+
+func (ds *Dispute) ListDisputes(ctx context.Context, status string, limit int) ([]sharedTypes.Dispute, error) {
+	sqlStatement := `
+	SELECT ` + disputeColumns + ` FROM ORDER_DISPUTES
+	WHERE $1 = '' OR status = $1
+	ORDER BY id DESC
+	LIMIT $2
+	`
+
+	return ds.queryDisputes(ctx, sqlStatement, status, limit)
+}
+
+func (ds *Dispute) queryDisputes(ctx context.Context, sqlStatement string, args ...interface{}) ([]sharedTypes.Dispute, error) {
+	rows, err := ds.Conn.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	disputes := []sharedTypes.Dispute{}
+
+	for rows.Next() {
+		entry := sharedTypes.Dispute{}
+
+		err = scanDispute(rows, &entry)
+		if err != nil {
+			return nil, err
+		}
+
+		disputes = append(disputes, entry)
+	}
+
+	return disputes, rows.Err()
+}
+
+// ResolveDispute closes an open dispute. On reassignment the order moves to
+// the claimant together with any points it has already earned.
+func (ds *Dispute) ResolveDispute(ctx context.Context, d sharedTypes.Dispute, reassign bool) (sharedTypes.Dispute, error) {
+	err := pgx.BeginFunc(ctx, ds.Conn, func(tx pgx.Tx) error {
+		var current sharedTypes.Dispute
+
+		err := scanDispute(tx.QueryRow(ctx, `SELECT `+disputeColumns+` FROM ORDER_DISPUTES WHERE id = $1 FOR UPDATE`, d.ID), &current)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if current.Status != sharedTypes.DisputeStatusOpen {
+			return utils.ErrDisputeClosed
+		}
+
+		status := sharedTypes.DisputeStatusRejected
+
+		if reassign {
+			status = sharedTypes.DisputeStatusReassigned
+
+			err = reassignOrder(ctx, tx, current)
+			if err != nil {
+				return err
+			}
+		}
+
+		sqlClose := `
+		UPDATE ORDER_DISPUTES SET status = $2, resolution = NULLIF($3, ''), resolved_by = $4, resolved_at = current_timestamp
+		WHERE id = $1
+		RETURNING ` + disputeColumns
+
+		return scanDispute(tx.QueryRow(ctx, sqlClose, d.ID, status, d.Resolution, d.ResolvedBy), &d)
+	})
+
+	return d, err
+}
+
+func reassignOrder(ctx context.Context, tx pgx.Tx, d sharedTypes.Dispute) error {
+	var (
+		owner, status string
+		householdID   *string
+		accrual       float32
+	)
+
+	sqlOrder := `SELECT uid, status, net_accrual, household_id::text FROM orders WHERE id = $1 FOR UPDATE`
+
+	err := tx.QueryRow(ctx, sqlOrder, d.Number).Scan(&owner, &status, &accrual, &householdID)
+	if err != nil {
+		return err
+	}
+
+	if owner != d.HolderUID {
+		return utils.ErrDisputeStale
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE orders SET uid = $2, household_id = NULL WHERE id = $1`, d.Number, d.ClaimantUID)
+	if err != nil {
+		return err
+	}
+
+	if accrual > 0 {
+		err = debitAccrual(ctx, tx, d.HolderUID, householdID, accrual)
+		if err != nil {
+			return err
+		}
+
+		// the claimant earns into their own household pool if they have one
+		err = creditAccrual(ctx, tx, d.ClaimantUID, d.Number, accrual)
+		if err != nil {
+			return err
+		}
+	}
+
+	sqlHistory := `
+	INSERT INTO ORDER_HISTORY (order_id, uid, event, status, previous_uid)
+	VALUES ($1, $2, 'reassigned', $3, $4)
+	`
+
+	_, err = tx.Exec(ctx, sqlHistory, d.Number, d.ClaimantUID, status, d.HolderUID)
+
+	return err
+}
+
+// debitAccrual takes an order's accrual back from wherever it was credited:
+// the household pool when the order earned into one, the holder otherwise.
+func debitAccrual(ctx context.Context, tx pgx.Tx, holderUID string, householdID *string, accrual float32) error {
+	if householdID != nil {
+		sqlPool := `UPDATE HOUSEHOLDS SET balance = balance - $2 WHERE id = $1 AND balance >= $2`
+
+		tag, err := tx.Exec(ctx, sqlPool, *householdID, accrual)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return utils.ErrPaymentError
+		}
+
+		return consumePoolLots(ctx, tx, *householdID, accrual)
+	}
+
+	sqlDebit := `
+	UPDATE USERS SET current_balance = current_balance - $2
+	WHERE uid = $1 AND current_balance >= $2
+	`
+
+	tag, err := tx.Exec(ctx, sqlDebit, holderUID, accrual)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return utils.ErrPaymentError
+	}
+
+	return consumeLots(ctx, tx, holderUID, accrual)
+}
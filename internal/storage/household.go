@@ -0,0 +1,524 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// householdSpent is what member m took from the pool this calendar month.
+const householdSpent = `(
+	SELECT COALESCE(SUM(w.sum), 0) FROM WITHDRAWALS w
+	WHERE w.household_id = m.household_id AND w.uid = m.uid AND w.processed_at >= date_trunc('month', current_timestamp)
+)`
+
+type Household struct {
+	Conn *pgxpool.Pool
+}
+
+func InitHousehold(conn *pgxpool.Pool) (*Household, error) {
+	return &Household{conn}, nil
+}
+
+func (hs *Household) CreateHousehold(ctx context.Context, ownerUID, name string) (sharedTypes.Household, error) {
+	h := sharedTypes.Household{Name: name, OwnerUID: ownerUID}
+
+	err := pgx.BeginFunc(ctx, hs.Conn, func(tx pgx.Tx) error {
+		sqlHousehold := `
+		INSERT INTO HOUSEHOLDS (name, owner_uid)
+		VALUES ($1, $2)
+		RETURNING id, created_at::timestamptz
+		`
+
+		err := tx.QueryRow(ctx, sqlHousehold, name, ownerUID).Scan(&h.ID, &h.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO HOUSEHOLD_MEMBERS (uid, household_id) VALUES ($1, $2)`, ownerUID, h.ID)
+
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return h, utils.ErrHouseholdTaken
+	}
+
+	return h, err
+}
+
+// GetHousehold returns the household the user belongs to with all its members.
+func (hs *Household) GetHousehold(ctx context.Context, uid string) (sharedTypes.Household, error) {
+	sqlHousehold := `
+	SELECT h.id, h.name, h.owner_uid, h.balance, h.created_at::timestamptz FROM HOUSEHOLDS h
+	JOIN HOUSEHOLD_MEMBERS m ON m.household_id = h.id
+	WHERE m.uid = $1
+	`
+
+	h := sharedTypes.Household{}
+	err := hs.Conn.QueryRow(ctx, sqlHousehold, uid).Scan(&h.ID, &h.Name, &h.OwnerUID, &h.Balance, &h.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return h, utils.ErrNotFound
+	}
+
+	if err != nil {
+		return h, err
+	}
+
+	sqlMembers := `
+	SELECT m.uid, COALESCE(u.login, ''), m.spend_limit, m.joined_at::timestamptz, ` + householdSpent + `
+	FROM HOUSEHOLD_MEMBERS m
+	JOIN USERS u ON u.uid = m.uid
+	WHERE m.household_id = $1
+	ORDER BY m.joined_at, m.uid
+	`
+
+	rows, err := hs.Conn.Query(ctx, sqlMembers, h.ID)
+	if err != nil {
+		return h, err
+	}
+
+	defer rows.Close()
+
+	h.Members = []sharedTypes.HouseholdMember{}
+
+	for rows.Next() {
+		m := sharedTypes.HouseholdMember{}
+		err = rows.Scan(&m.UID, &m.Login, &m.SpendLimit, &m.JoinedAt, &m.Spent)
+
+		if err != nil {
+			return h, err
+		}
+
+		m.Owner = m.UID == h.OwnerUID
+		h.Members = append(h.Members, m)
+	}
+
+	return h, rows.Err()
+}
+
+func (hs *Household) GetMemberBalance(ctx context.Context, uid string) (sharedTypes.HouseholdBalance, error) {
+	sqlStatement := `
+	SELECT h.id, h.name, h.balance, m.spend_limit, ` + householdSpent + ` FROM HOUSEHOLD_MEMBERS m
+	JOIN HOUSEHOLDS h ON h.id = m.household_id
+	WHERE m.uid = $1
+	`
+
+	b := sharedTypes.HouseholdBalance{}
+	err := hs.Conn.QueryRow(ctx, sqlStatement, uid).Scan(&b.ID, &b.Name, &b.Current, &b.SpendLimit, &b.Spent)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return b, utils.ErrNotFound
+	}
+
+	return b, err
+}
+
+// CreateInvite invites an active user who does not belong to a household yet.
+func (hs *Household) CreateInvite(ctx context.Context, householdID, login string) (sharedTypes.HouseholdInvite, error) {
+	sqlUser := `
+	SELECT u.uid, EXISTS (SELECT 1 FROM HOUSEHOLD_MEMBERS m WHERE m.uid = u.uid) FROM USERS u
+	WHERE u.login = $1 AND u.deleted_at IS NULL AND u.merged_into IS NULL AND u.suspended_at IS NULL
+	`
+
+	invite := sharedTypes.HouseholdInvite{HouseholdID: householdID}
+
+	var member bool
+	err := hs.Conn.QueryRow(ctx, sqlUser, login).Scan(&invite.UID, &member)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return invite, utils.ErrNotFound
+	}
+
+	if err != nil {
+		return invite, err
+	}
+
+	if member {
+		return invite, utils.ErrHouseholdTaken
+	}
+
+	sqlInsert := `
+	INSERT INTO HOUSEHOLD_INVITES (household_id, uid)
+	VALUES ($1, $2)
+	ON CONFLICT (household_id, uid) DO NOTHING
+	RETURNING id, created_at::timestamptz
+	`
+
+	err = hs.Conn.QueryRow(ctx, sqlInsert, householdID, invite.UID).Scan(&invite.ID, &invite.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return invite, utils.ErrDuplicate
+	}
+
+	return invite, err
+}
+
+func (hs *Household) ListInvites(ctx context.Context, uid string) ([]sharedTypes.HouseholdInvite, error) {
+	sqlStatement := `
+	SELECT i.id, i.household_id, h.name, COALESCE(o.login, ''), i.created_at::timestamptz FROM HOUSEHOLD_INVITES i
+	JOIN HOUSEHOLDS h ON h.id = i.household_id
+	JOIN USERS o ON o.uid = h.owner_uid
+	WHERE i.uid = $1
+	ORDER BY i.created_at DESC, i.id DESC
+	`
+
+	rows, err := hs.Conn.Query(ctx, sqlStatement, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	invites := []sharedTypes.HouseholdInvite{}
+
+	for rows.Next() {
+		i := sharedTypes.HouseholdInvite{UID: uid}
+		err = rows.Scan(&i.ID, &i.HouseholdID, &i.Household, &i.Owner, &i.CreatedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		invites = append(invites, i)
+	}
+
+	return invites, rows.Err()
+}
+
+// AcceptInvite joins the household and drops the user's other invites. It
+// returns the id of the household joined.
+func (hs *Household) AcceptInvite(ctx context.Context, uid, id string) (string, error) {
+	var householdID string
+
+	err := pgx.BeginFunc(ctx, hs.Conn, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `DELETE FROM HOUSEHOLD_INVITES WHERE id = $1 AND uid = $2 RETURNING household_id`, id, uid).Scan(&householdID)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO HOUSEHOLD_MEMBERS (uid, household_id) VALUES ($1, $2)`, uid, householdID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM HOUSEHOLD_INVITES WHERE uid = $1`, uid)
+
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return "", utils.ErrHouseholdTaken
+	}
+
+	return householdID, err
+}
+
+func (hs *Household) DeclineInvite(ctx context.Context, uid, id string) (bool, error) {
+	tag, err := hs.Conn.Exec(ctx, `DELETE FROM HOUSEHOLD_INVITES WHERE id = $1 AND uid = $2`, id, uid)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// RemoveMember never removes the owner; the household has to be dissolved instead.
+func (hs *Household) RemoveMember(ctx context.Context, householdID, uid string) (bool, error) {
+	sqlStatement := `
+	DELETE FROM HOUSEHOLD_MEMBERS m
+	USING HOUSEHOLDS h
+	WHERE m.household_id = $1 AND m.uid = $2 AND h.id = m.household_id AND h.owner_uid <> m.uid
+	`
+
+	tag, err := hs.Conn.Exec(ctx, sqlStatement, householdID, uid)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (hs *Household) SetSpendLimit(ctx context.Context, householdID, uid string, limit *float32) (bool, error) {
+	sqlStatement := `
+	UPDATE HOUSEHOLD_MEMBERS SET spend_limit = $3
+	WHERE household_id = $1 AND uid = $2
+	`
+
+	tag, err := hs.Conn.Exec(ctx, sqlStatement, householdID, uid, limit)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// DissolveHousehold hands whatever is left in the pool to the owner and
+// returns that amount. A pool pushed negative by refunds is settled the same way.
+func (hs *Household) DissolveHousehold(ctx context.Context, householdID, ownerUID string) (float32, error) {
+	var pool float32
+
+	err := pgx.BeginFunc(ctx, hs.Conn, func(tx pgx.Tx) error {
+		var id string
+		err := tx.QueryRow(ctx, `SELECT id FROM HOUSEHOLDS WHERE id = $1 AND owner_uid = $2 FOR UPDATE`, householdID, ownerUID).Scan(&id)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		pool, err = dissolveHousehold(ctx, tx, householdID, ownerUID)
+
+		return err
+	})
+
+	return pool, err
+}
+
+// dissolveHousehold deletes the household and credits its pool to toUID. The
+// pool's open lots move over with their original age, so dissolving never
+// extends the life of the points.
+func dissolveHousehold(ctx context.Context, tx pgx.Tx, householdID, toUID string) (float32, error) {
+	var before, pool float32
+
+	err := tx.QueryRow(ctx, `SELECT current_balance FROM USERS WHERE uid = $1 FOR UPDATE`, toUID).Scan(&before)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlLots := `
+	UPDATE ACCRUAL_LOTS SET household_id = NULL, uid = CASE WHEN remaining > 0 THEN $2 ELSE uid END
+	WHERE household_id = $1
+	`
+
+	_, err = tx.Exec(ctx, sqlLots, householdID, toUID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.QueryRow(ctx, `DELETE FROM HOUSEHOLDS WHERE id = $1 RETURNING balance`, householdID).Scan(&pool)
+	if err != nil || pool == 0 {
+		return pool, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance + $2 WHERE uid = $1`, toUID, pool)
+	if err != nil {
+		return pool, err
+	}
+
+	if pool < 0 {
+		return pool, consumeLots(ctx, tx, toUID, -pool)
+	}
+
+	// Only the part that lifts the balance above zero stays spendable; the
+	// rest of the moved lots pays off the debt.
+	if before < 0 {
+		debt := -before
+		if debt > pool {
+			debt = pool
+		}
+
+		return pool, consumeLots(ctx, tx, toUID, debt)
+	}
+
+	return pool, nil
+}
+
+// WithdrawHousehold spends from the pool of the user's household. The pool row
+// is locked before the balance and the member's monthly limit are read, so
+// members spending at once on any instance cannot overdraw the pool or their limit.
+func (hs *Household) WithdrawHousehold(ctx context.Context, uid, orderID string, amount float32) (sharedTypes.HouseholdBalance, error) {
+	b := sharedTypes.HouseholdBalance{}
+
+	err := pgx.BeginFunc(ctx, hs.Conn, func(tx pgx.Tx) error {
+		sqlLock := `
+		SELECT h.id FROM HOUSEHOLDS h
+		JOIN HOUSEHOLD_MEMBERS m ON m.household_id = h.id
+		WHERE m.uid = $1
+		FOR UPDATE OF h
+		`
+
+		err := tx.QueryRow(ctx, sqlLock, uid).Scan(&b.ID)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		sqlState := `
+		SELECT h.name, h.balance, m.spend_limit, ` + householdSpent + `, u.frozen_at IS NOT NULL FROM HOUSEHOLD_MEMBERS m
+		JOIN HOUSEHOLDS h ON h.id = m.household_id
+		JOIN USERS u ON u.uid = m.uid
+		WHERE m.uid = $1
+		`
+
+		var frozen bool
+
+		err = tx.QueryRow(ctx, sqlState, uid).Scan(&b.Name, &b.Current, &b.SpendLimit, &b.Spent, &frozen)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case frozen:
+			return utils.ErrFrozen
+		case b.Current-amount < 0:
+			return utils.ErrPaymentError
+		case b.SpendLimit != nil && b.Spent+amount > *b.SpendLimit:
+			return utils.ErrSpendLimit
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE HOUSEHOLDS SET balance = balance - $2 WHERE id = $1`, b.ID, amount)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO WITHDRAWALS (id, sum, uid, household_id) VALUES ($1, $2, $3, $4)`, orderID, amount, uid, b.ID)
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return utils.ErrDuplicate
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = consumePoolLots(ctx, tx, b.ID, amount)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE USERS SET withdrawn = withdrawn + $2 WHERE uid = $1`, uid, amount)
+
+		b.Current -= amount
+		b.Spent += amount
+
+		return err
+	})
+
+	return b, err
+}
+
+// mergeHouseholds hands the source account's household place to the target
+// when two accounts are merged. A target that already belongs elsewhere keeps
+// its own household; the source's then passes to its longest-standing member,
+// or is dissolved into the target if nobody is left.
+func mergeHouseholds(ctx context.Context, tx pgx.Tx, sourceUID, targetUID string) error {
+	sqlPlace := `
+	SELECT m.household_id, h.owner_uid = m.uid FROM HOUSEHOLD_MEMBERS m
+	JOIN HOUSEHOLDS h ON h.id = m.household_id
+	WHERE m.uid = $1
+	FOR UPDATE OF h
+	`
+
+	var (
+		sourceID, targetID string
+		owner              bool
+	)
+
+	err := tx.QueryRow(ctx, sqlPlace, targetUID).Scan(&targetID, new(bool))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, sqlPlace, sourceUID).Scan(&sourceID, &owner)
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return err
+	case targetID == "":
+		_, err = tx.Exec(ctx, `UPDATE HOUSEHOLD_MEMBERS SET uid = $2 WHERE uid = $1`, sourceUID, targetUID)
+		if err != nil {
+			return err
+		}
+
+		if owner {
+			_, err = tx.Exec(ctx, `UPDATE HOUSEHOLDS SET owner_uid = $2 WHERE id = $1`, sourceID, targetUID)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		_, err = tx.Exec(ctx, `DELETE FROM HOUSEHOLD_MEMBERS WHERE uid = $1`, sourceUID)
+		if err != nil {
+			return err
+		}
+
+		if owner {
+			err = handOverHousehold(ctx, tx, sourceID, targetID, targetUID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE HOUSEHOLD_INVITES i SET uid = $2 WHERE uid = $1
+	AND NOT EXISTS (SELECT 1 FROM HOUSEHOLD_INVITES WHERE household_id = i.household_id AND uid = $2)`, sourceUID, targetUID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM HOUSEHOLD_INVITES WHERE uid = $1`, sourceUID)
+
+	return err
+}
+
+// handOverHousehold finds a new owner for a household whose owner left it
+// during a merge. When the target is already a member it takes over.
+func handOverHousehold(ctx context.Context, tx pgx.Tx, householdID, targetHouseholdID, targetUID string) error {
+	var successor string
+
+	if householdID == targetHouseholdID {
+		successor = targetUID
+	} else {
+		sqlSuccessor := `
+		SELECT uid FROM HOUSEHOLD_MEMBERS WHERE household_id = $1
+		ORDER BY joined_at, uid
+		LIMIT 1
+		`
+
+		err := tx.QueryRow(ctx, sqlSuccessor, householdID).Scan(&successor)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			_, err = dissolveHousehold(ctx, tx, householdID, targetUID)
+
+			return err
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE HOUSEHOLDS SET owner_uid = $2 WHERE id = $1`, householdID, successor)
+	if err != nil {
+		return err
+	}
+
+	// owners spend without a limit
+	_, err = tx.Exec(ctx, `UPDATE HOUSEHOLD_MEMBERS SET spend_limit = NULL WHERE uid = $1`, successor)
+
+	return err
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Job schedules periodic work across instances. A session advisory lock keeps
+// runs from overlapping and JOB_RUNS remembers when the job last finished, so
+// neither restarts nor extra instances skip or repeat a run.
+type Job struct {
+	Conn *pgxpool.Pool
+}
+
+func InitJob(conn *pgxpool.Pool) (*Job, error) {
+	return &Job{conn}, nil
+}
+
+// ClaimJob takes the job's lock when the job is due. The caller must call
+// release, passing whether the run finished, which records the run and frees
+// the lock.
+func (j *Job) ClaimJob(ctx context.Context, name string, interval uint) (func(done bool) error, bool, error) {
+	conn, err := j.Conn.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var locked bool
+
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&locked)
+	if err != nil || !locked {
+		conn.Release()
+		return nil, false, err
+	}
+
+	// the lock belongs to the session, so a connection that cannot be
+	// unlocked is closed rather than handed back to the pool
+	unlock := func() {
+		_, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, name)
+		if err != nil {
+			conn.Conn().Close(context.Background())
+		}
+
+		conn.Release()
+	}
+
+	sqlDue := `
+	SELECT NOT EXISTS (
+		SELECT 1 FROM JOB_RUNS WHERE name = $1 AND finished_at > current_timestamp - make_interval(secs => $2::integer)
+	)
+	`
+
+	var due bool
+
+	err = conn.QueryRow(ctx, sqlDue, name, interval).Scan(&due)
+	if err != nil || !due {
+		unlock()
+		return nil, false, err
+	}
+
+	release := func(done bool) error {
+		defer unlock()
+
+		if !done {
+			return nil
+		}
+
+		sqlFinish := `
+		INSERT INTO JOB_RUNS (name, finished_at) VALUES ($1, current_timestamp)
+		ON CONFLICT (name) DO UPDATE SET finished_at = excluded.finished_at
+		`
+
+		_, err := conn.Exec(context.Background(), sqlFinish, name)
+
+		return err
+	}
+
+	return release, true, nil
+}
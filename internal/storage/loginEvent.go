@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LoginEvent struct {
+	Conn *pgxpool.Pool
+}
+
+func InitLoginEvent(conn *pgxpool.Pool) (*LoginEvent, error) {
+	return &LoginEvent{conn}, nil
+}
+
+// StartLoginAttempt counts failed attempts inside the window, per login (reset
+// by a successful login) and per IP, and records this attempt before anyone
+// else can count. Attempts are serialised per login and per IP, and one still
+// in flight counts as a failure, so parallel guesses cannot all slip under the
+// limit. The returned event carries the attempt's id for FinishLoginAttempt.
+func (le *LoginEvent) StartLoginAttempt(ctx context.Context, event sharedTypes.LoginEvent, window uint) (sharedTypes.LoginEvent, sharedTypes.LoginFailures, error) {
+	var f sharedTypes.LoginFailures
+
+	err := pgx.BeginFunc(ctx, le.Conn, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('login:' || $1))`, event.Login)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('ip:' || $1))`, event.IP)
+		if err != nil {
+			return err
+		}
+
+		sqlFailures := `
+		WITH since AS (
+			SELECT GREATEST(
+				now()::timestamp - make_interval(secs => $3::integer),
+				(SELECT MAX(created_at) FROM LOGIN_EVENTS WHERE login = $1 AND outcome = 'success')
+			) AS login_since
+		)
+		SELECT
+			COUNT(*) FILTER (WHERE login = $1 AND created_at > (SELECT login_since FROM since)),
+			(MAX(created_at) FILTER (WHERE login = $1))::timestamptz,
+			COUNT(*) FILTER (WHERE ip = $2),
+			(MAX(created_at) FILTER (WHERE ip = $2))::timestamptz
+		FROM LOGIN_EVENTS
+		WHERE outcome IN ('failure', 'attempt')
+			AND created_at > now()::timestamp - make_interval(secs => $3::integer)
+			AND (login = $1 OR ip = $2)
+		`
+
+		var loginLast, ipLast *time.Time
+		err = tx.QueryRow(ctx, sqlFailures, event.Login, event.IP, window).Scan(&f.LoginCount, &loginLast, &f.IPCount, &ipLast)
+
+		if err != nil {
+			return err
+		}
+
+		if loginLast != nil {
+			f.LoginLast = *loginLast
+		}
+
+		if ipLast != nil {
+			f.IPLast = *ipLast
+		}
+
+		sqlAttempt := `
+		INSERT INTO LOGIN_EVENTS (login, uid, ip, user_agent, outcome)
+		VALUES ($1, NULLIF($2, '')::integer, $3, $4, 'attempt')
+		RETURNING id
+		`
+
+		return tx.QueryRow(ctx, sqlAttempt, event.Login, event.UID, event.IP, event.UserAgent).Scan(&event.ID)
+	})
+
+	if err != nil {
+		return event, sharedTypes.LoginFailures{}, err
+	}
+
+	return event, f, nil
+}
+
+// FinishLoginAttempt records how an attempt started by StartLoginAttempt ended.
+func (le *LoginEvent) FinishLoginAttempt(ctx context.Context, event sharedTypes.LoginEvent) error {
+	sqlStatement := `
+	UPDATE LOGIN_EVENTS SET uid = NULLIF($2, '')::integer, outcome = $3
+	WHERE id = $1
+	`
+
+	_, err := le.Conn.Exec(ctx, sqlStatement, event.ID, event.UID, event.Outcome)
+
+	return err
+}
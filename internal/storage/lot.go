@@ -0,0 +1,332 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	lotSourceOrder      = "order"
+	lotSourceAdjustment = "adjustment"
+	lotSourceCampaign   = "campaign"
+	lotSourcePromo      = "promo"
+	lotSourceReferral   = "referral"
+	lotSourceTransfer   = "transfer"
+)
+
+// A lot belongs either to a user or, when household_id is set, to that
+// household's pool; uid then names the member who earned it.
+const (
+	personalLots = `uid = $1 AND household_id IS NULL`
+	poolLots     = `household_id = $1`
+)
+
+// Lot tracks every credit as an accrual lot so points can be spent oldest
+// first and expire a fixed number of days after they were earned.
+type Lot struct {
+	Conn *pgxpool.Pool
+}
+
+func InitLot(conn *pgxpool.Pool) (*Lot, error) {
+	return &Lot{conn}, nil
+}
+
+func (l *Lot) ConsumeLots(ctx context.Context, uid string, amount float32) error {
+	return pgx.BeginFunc(ctx, l.Conn, func(tx pgx.Tx) error {
+		return consumeLots(ctx, tx, uid, amount)
+	})
+}
+
+func (l *Lot) ListExpiringPoints(ctx context.Context, uid string, lifetimeDays int) ([]sharedTypes.PointsExpiry, error) {
+	sqlStatement := `
+	SELECT (created_at + make_interval(days => $2::integer))::timestamptz AS expires_at, SUM(remaining) FROM ACCRUAL_LOTS
+	WHERE ` + personalLots + ` AND remaining > 0
+	GROUP BY expires_at
+	ORDER BY expires_at
+	`
+
+	rows, err := l.Conn.Query(ctx, sqlStatement, uid, lifetimeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := []sharedTypes.PointsExpiry{}
+
+	for rows.Next() {
+		entry := sharedTypes.PointsExpiry{}
+		err = rows.Scan(&entry.ExpiresAt, &entry.Points)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, entry)
+	}
+
+	return list, rows.Err()
+}
+
+// ListUsersWithExpiredLots pages through the users with expired lots in uid
+// order, starting after afterUID.
+func (l *Lot) ListUsersWithExpiredLots(ctx context.Context, lifetimeDays int, afterUID string, limit int) ([]string, error) {
+	sqlStatement := `
+	SELECT DISTINCT uid FROM ACCRUAL_LOTS
+	WHERE household_id IS NULL AND remaining > 0 AND created_at <= current_timestamp - make_interval(days => $1::integer) AND uid > $2
+	ORDER BY uid
+	LIMIT $3
+	`
+
+	rows, err := l.Conn.Query(ctx, sqlStatement, lifetimeDays, afterUID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	uids := []string{}
+
+	for rows.Next() {
+		var uid string
+
+		err = rows.Scan(&uid)
+		if err != nil {
+			return nil, err
+		}
+
+		uids = append(uids, uid)
+	}
+
+	return uids, rows.Err()
+}
+
+// ExpireLots closes the user's lots older than the lifetime and debits what is
+// left of them, recording the debit as an expiry adjustment. A balance that is
+// already negative is not pushed further down, so the returned adjustment may
+// be empty.
+func (l *Lot) ExpireLots(ctx context.Context, adj sharedTypes.Adjustment, lifetimeDays int) (sharedTypes.Adjustment, error) {
+	err := pgx.BeginFunc(ctx, l.Conn, func(tx pgx.Tx) error {
+		var balance float32
+
+		err := tx.QueryRow(ctx, `SELECT GREATEST(current_balance, 0) FROM USERS WHERE uid = $1 FOR UPDATE`, adj.UID).Scan(&balance)
+		if err != nil {
+			return err
+		}
+
+		expired, err := expireLots(ctx, tx, personalLots, adj.UID, lifetimeDays)
+		if err != nil {
+			return err
+		}
+
+		if balance < expired {
+			expired = balance
+		}
+
+		if expired == 0 {
+			return nil
+		}
+
+		adj.Amount = -expired
+
+		_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance - $2 WHERE uid = $1`, adj.UID, expired)
+		if err != nil {
+			return err
+		}
+
+		sqlInsert := `
+		INSERT INTO BALANCE_ADJUSTMENTS (uid, admin_uid, amount, reason, comment)
+		VALUES ($1, NULL, $2, $3, $4)
+		RETURNING id, created_at::timestamptz
+		`
+
+		return tx.QueryRow(ctx, sqlInsert, adj.UID, adj.Amount, adj.Reason, adj.Comment).Scan(&adj.ID, &adj.CreatedAt)
+	})
+
+	return adj, err
+}
+
+// ListHouseholdsWithExpiredLots pages through the household pools with expired
+// lots in id order, starting after afterID.
+func (l *Lot) ListHouseholdsWithExpiredLots(ctx context.Context, lifetimeDays int, afterID string, limit int) ([]string, error) {
+	sqlStatement := `
+	SELECT DISTINCT household_id FROM ACCRUAL_LOTS
+	WHERE household_id IS NOT NULL AND remaining > 0 AND created_at <= current_timestamp - make_interval(days => $1::integer) AND household_id > $2
+	ORDER BY household_id
+	LIMIT $3
+	`
+
+	rows, err := l.Conn.Query(ctx, sqlStatement, lifetimeDays, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := []string{}
+
+	for rows.Next() {
+		var id string
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// ExpireHouseholdLots is ExpireLots for a household pool. It returns how much
+// the pool lost.
+func (l *Lot) ExpireHouseholdLots(ctx context.Context, householdID string, lifetimeDays int) (float32, error) {
+	var expired float32
+
+	err := pgx.BeginFunc(ctx, l.Conn, func(tx pgx.Tx) error {
+		var balance float32
+
+		err := tx.QueryRow(ctx, `SELECT GREATEST(balance, 0) FROM HOUSEHOLDS WHERE id = $1 FOR UPDATE`, householdID).Scan(&balance)
+		if err != nil {
+			return err
+		}
+
+		expired, err = expireLots(ctx, tx, poolLots, householdID, lifetimeDays)
+		if err != nil {
+			return err
+		}
+
+		if balance < expired {
+			expired = balance
+		}
+
+		if expired == 0 {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE HOUSEHOLDS SET balance = balance - $2 WHERE id = $1`, householdID, expired)
+
+		return err
+	})
+
+	return expired, err
+}
+
+// expireLots closes the owner's lots older than the lifetime and returns what
+// was left of them.
+func expireLots(ctx context.Context, tx pgx.Tx, owner, id string, lifetimeDays int) (float32, error) {
+	sqlExpire := `
+	WITH expired AS (
+		SELECT id, remaining FROM ACCRUAL_LOTS
+		WHERE ` + owner + ` AND remaining > 0 AND created_at <= current_timestamp - make_interval(days => $2::integer)
+		FOR UPDATE
+	), closed AS (
+		UPDATE ACCRUAL_LOTS l SET remaining = 0, expired_at = current_timestamp
+		FROM expired e WHERE l.id = e.id
+	)
+	SELECT COALESCE(SUM(remaining), 0) FROM expired
+	`
+
+	var expired float32
+	err := tx.QueryRow(ctx, sqlExpire, id, lifetimeDays).Scan(&expired)
+
+	return expired, err
+}
+
+// addPoolLot is addLot for a credit to a household pool.
+func addPoolLot(ctx context.Context, tx pgx.Tx, householdID, uid, source, ref string, amount float32) error {
+	sqlStatement := `
+	INSERT INTO ACCRUAL_LOTS (uid, household_id, source, ref, amount, remaining)
+	SELECT $2, id, $3, NULLIF($4, ''), $5, LEAST($5::real, balance) FROM HOUSEHOLDS
+	WHERE id = $1 AND balance > 0
+	`
+
+	_, err := tx.Exec(ctx, sqlStatement, householdID, uid, source, ref, amount)
+
+	return err
+}
+
+// addLot records a credit that has already been added to the balance. Only
+// the part that lifts the balance above zero becomes spendable; the rest pays
+// off a negative balance.
+func addLot(ctx context.Context, tx pgx.Tx, uid, source, ref string, amount float32) error {
+	sqlStatement := `
+	INSERT INTO ACCRUAL_LOTS (uid, source, ref, amount, remaining)
+	SELECT uid, $2, NULLIF($3, ''), $4, LEAST($4::real, current_balance) FROM USERS
+	WHERE uid = $1 AND current_balance > 0
+	`
+
+	_, err := tx.Exec(ctx, sqlStatement, uid, source, ref, amount)
+
+	return err
+}
+
+// moveLots spends the sender's lots and credits the recipient with a lot as old
+// as the oldest one spent, so passing points around never extends their life.
+// The recipient's balance must already include the amount.
+func moveLots(ctx context.Context, tx pgx.Tx, from, to, ref string, amount float32) error {
+	sqlOldest := `
+	SELECT MIN(created_at) FROM (
+		SELECT created_at, SUM(remaining) OVER (ORDER BY created_at, id) - remaining AS spent_before FROM ACCRUAL_LOTS
+		WHERE ` + personalLots + ` AND remaining > 0
+	) o
+	WHERE o.spent_before < $2::real
+	`
+
+	var oldest *time.Time
+
+	err := tx.QueryRow(ctx, sqlOldest, from, amount).Scan(&oldest)
+	if err != nil {
+		return err
+	}
+
+	err = consumeLots(ctx, tx, from, amount)
+	if err != nil {
+		return err
+	}
+
+	sqlInsert := `
+	INSERT INTO ACCRUAL_LOTS (uid, source, ref, amount, remaining, created_at)
+	SELECT uid, $2, $3, $4, LEAST($4::real, current_balance), COALESCE($5, current_timestamp) FROM USERS
+	WHERE uid = $1 AND current_balance > 0
+	`
+
+	_, err = tx.Exec(ctx, sqlInsert, to, lotSourceTransfer, ref, amount, oldest)
+
+	return err
+}
+
+// consumeLots spends the user's open lots oldest first.
+func consumeLots(ctx context.Context, tx pgx.Tx, uid string, amount float32) error {
+	return spendLots(ctx, tx, personalLots, uid, amount)
+}
+
+// consumePoolLots spends the household pool's open lots oldest first.
+func consumePoolLots(ctx context.Context, tx pgx.Tx, householdID string, amount float32) error {
+	return spendLots(ctx, tx, poolLots, householdID, amount)
+}
+
+func spendLots(ctx context.Context, tx pgx.Tx, owner, id string, amount float32) error {
+	_, err := tx.Exec(ctx, `SELECT id FROM ACCRUAL_LOTS WHERE `+owner+` AND remaining > 0 FOR UPDATE`, id)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement := `
+	UPDATE ACCRUAL_LOTS l SET remaining = l.remaining - LEAST(l.remaining, $2::real - o.spent_before)
+	FROM (
+		SELECT id, SUM(remaining) OVER (ORDER BY created_at, id) - remaining AS spent_before FROM ACCRUAL_LOTS
+		WHERE ` + owner + ` AND remaining > 0
+	) o
+	WHERE l.id = o.id AND o.spent_before < $2::real
+	`
+
+	_, err = tx.Exec(ctx, sqlStatement, id, amount)
+
+	return err
+}
@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Merchant struct {
+	Conn *pgxpool.Pool
+}
+
+func InitMerchant(conn *pgxpool.Pool) (*Merchant, error) {
+	return &Merchant{conn}, nil
+}
+
+func (m *Merchant) CreateMerchant(ctx context.Context, merchant sharedTypes.Merchant) (sharedTypes.Merchant, error) {
+	sqlStatement := `
+	INSERT INTO MERCHANTS (name, prefix, key_hash, created_by)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at::timestamptz
+	`
+
+	err := m.Conn.QueryRow(ctx, sqlStatement, merchant.Name, merchant.Prefix, merchant.Hash, merchant.CreatedBy).Scan(&merchant.ID, &merchant.CreatedAt)
+
+	return merchant, err
+}
+
+func (m *Merchant) ListMerchants(ctx context.Context) ([]sharedTypes.Merchant, error) {
+	sqlStatement := `
+	SELECT id, name, prefix, created_at::timestamptz, revoked_at::timestamptz FROM MERCHANTS
+	ORDER BY id
+	`
+
+	rows, err := m.Conn.Query(ctx, sqlStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	merchants := []sharedTypes.Merchant{}
+
+	for rows.Next() {
+		entry := sharedTypes.Merchant{}
+		err = rows.Scan(&entry.ID, &entry.Name, &entry.Prefix, &entry.CreatedAt, &entry.RevokedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		merchants = append(merchants, entry)
+	}
+
+	return merchants, rows.Err()
+}
+
+func (m *Merchant) RevokeMerchant(ctx context.Context, id string) (bool, error) {
+	sqlStatement := `
+	UPDATE MERCHANTS SET revoked_at = current_timestamp
+	WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	tag, err := m.Conn.Exec(ctx, sqlStatement, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetMerchant only returns merchants whose credentials have not been revoked.
+func (m *Merchant) GetMerchant(ctx context.Context, prefix string) (sharedTypes.Merchant, error) {
+	sqlStatement := `
+	SELECT id, name, prefix, key_hash, created_at::timestamptz FROM MERCHANTS
+	WHERE prefix = $1 AND revoked_at IS NULL
+	`
+
+	var merchant sharedTypes.Merchant
+	err := m.Conn.QueryRow(ctx, sqlStatement, prefix).Scan(&merchant.ID, &merchant.Name, &merchant.Prefix, &merchant.Hash, &merchant.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return merchant, utils.ErrNotFound
+	}
+
+	return merchant, err
+}
+
+// FindCustomer resolves a customer by login or loyalty card and reports
+// whether the account is suspended.
+func (m *Merchant) FindCustomer(ctx context.Context, login, cardID string) (string, bool, error) {
+	sqlStatement := `
+	SELECT uid, suspended_at IS NOT NULL FROM USERS
+	WHERE (login = NULLIF($1, '') OR card_id = NULLIF($2, '')) AND deleted_at IS NULL
+	`
+
+	var (
+		uid       string
+		suspended bool
+	)
+
+	err := m.Conn.QueryRow(ctx, sqlStatement, login, cardID).Scan(&uid, &suspended)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", false, utils.ErrNotFound
+	}
+
+	return uid, suspended, err
+}
+
+// GetRefundableOrder only finds orders the merchant itself pushed.
+func (m *Merchant) GetRefundableOrder(ctx context.Context, merchantID, number string) (sharedTypes.RefundableOrder, error) {
+	sqlStatement := `
+	SELECT uid, status, COALESCE(accrual, 0) + bonus_accrual, net_accrual, COALESCE(total, 0), refunded FROM ORDERS
+	WHERE id = $1 AND merchant_id = $2
+	`
+
+	var order sharedTypes.RefundableOrder
+	err := m.Conn.QueryRow(ctx, sqlStatement, number, merchantID).Scan(&order.UID, &order.Status, &order.Accrual, &order.NetAccrual, &order.Total, &order.Refunded)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return order, utils.ErrNotFound
+	}
+
+	return order, err
+}
+
+// RefundOrder takes the clawback off the order and debits what the policy
+// allows from its owner, together with the matching share of the campaign
+// bonuses the order earned. The balance the accrual went to is locked before the
+// policy is applied, so a concurrent spend cannot slip under a floor or reject
+// decision. It fails with ErrOrderLocked when the order changed after prev was
+// read.
+func (m *Merchant) RefundOrder(ctx context.Context, refund sharedTypes.Refund, prev sharedTypes.RefundableOrder, policy string) (sharedTypes.Refund, error) {
+	err := pgx.BeginFunc(ctx, m.Conn, func(tx pgx.Tx) error {
+		sqlOrder := `
+		UPDATE ORDERS SET refunded = refunded + $3, net_accrual = net_accrual - $4
+		WHERE id = $1 AND uid = $2 AND refunded = $5 AND net_accrual = $6
+		RETURNING net_accrual, household_id::text
+		`
+
+		var householdID *string
+
+		err := tx.QueryRow(ctx, sqlOrder, refund.Number, refund.UID, refund.Amount, refund.Clawback, prev.Refunded, prev.NetAccrual).Scan(&refund.NetAccrual, &householdID)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrOrderLocked
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if refund.Clawback > 0 {
+			err = debitClawback(ctx, tx, &refund, householdID, policy)
+			if err != nil {
+				return err
+			}
+		}
+
+		// an order without a total can only be refunded once, in full
+		share := float32(1)
+		if prev.Total > 0 {
+			share = refund.Amount / prev.Total
+		}
+
+		err = clawbackCampaignRewards(ctx, tx, &refund, share, policy)
+		if err != nil {
+			return err
+		}
+
+		sqlHistory := `
+		INSERT INTO ORDER_HISTORY (order_id, uid, event, status, amount)
+		VALUES ($1, $2, 'refunded', $3, $4)
+		`
+
+		_, err = tx.Exec(ctx, sqlHistory, refund.Number, refund.UID, prev.Status, refund.Clawback)
+
+		return err
+	})
+
+	return refund, err
+}
+
+// debitClawback takes the clawback back from the household pool the accrual
+// went to, or from the owner otherwise. Under the floor policy the part the
+// locked balance cannot cover is written off instead.
+func debitClawback(ctx context.Context, tx pgx.Tx, refund *sharedTypes.Refund, householdID *string, policy string) error {
+	var (
+		balance float32
+		err     error
+	)
+
+	if householdID != nil {
+		err = tx.QueryRow(ctx, `SELECT balance FROM HOUSEHOLDS WHERE id = $1 FOR UPDATE`, *householdID).Scan(&balance)
+	} else {
+		err = tx.QueryRow(ctx, `SELECT current_balance FROM USERS WHERE uid = $1 FOR UPDATE`, refund.UID).Scan(&balance)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	debit, err := clawbackPolicy(refund.Clawback, balance, policy)
+	if err != nil {
+		return err
+	}
+
+	refund.WrittenOff += refund.Clawback - debit
+
+	if debit == 0 {
+		return nil
+	}
+
+	if householdID != nil {
+		_, err = tx.Exec(ctx, `UPDATE HOUSEHOLDS SET balance = balance - $2 WHERE id = $1`, *householdID, debit)
+		if err != nil {
+			return err
+		}
+
+		return consumePoolLots(ctx, tx, *householdID, debit)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance - $2 WHERE uid = $1`, refund.UID, debit)
+	if err != nil {
+		return err
+	}
+
+	return consumeLots(ctx, tx, refund.UID, debit)
+}
+
+// clawbackCampaignRewards takes back the owner's campaign bonuses earned on the
+// order, scaled to the refunded share, under the same policy as the accrual.
+func clawbackCampaignRewards(ctx context.Context, tx pgx.Tx, refund *sharedTypes.Refund, share float32, policy string) error {
+	var rewards, balance float32
+
+	sqlRewards := `SELECT COALESCE(SUM(amount), 0) FROM CAMPAIGN_REWARDS WHERE order_id = $1 AND uid = $2`
+
+	err := tx.QueryRow(ctx, sqlRewards, refund.Number, refund.UID).Scan(&rewards)
+	if err != nil || rewards <= 0 {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `SELECT current_balance FROM USERS WHERE uid = $1 FOR UPDATE`, refund.UID).Scan(&balance)
+	if err != nil {
+		return err
+	}
+
+	refund.CampaignClawback = rewards * share
+
+	debit, err := clawbackPolicy(refund.CampaignClawback, balance, policy)
+	if err != nil {
+		return err
+	}
+
+	refund.WrittenOff += refund.CampaignClawback - debit
+
+	if debit == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance - $2 WHERE uid = $1`, refund.UID, debit)
+	if err != nil {
+		return err
+	}
+
+	return consumeLots(ctx, tx, refund.UID, debit)
+}
+
+// clawbackPolicy returns how much of amount to debit from a balance: all of it
+// under the negative policy, at most what is left above zero under the floor
+// policy, and nothing but ErrPaymentError under reject when it does not fit.
+func clawbackPolicy(amount, balance float32, policy string) (float32, error) {
+	if balance < 0 {
+		balance = 0
+	}
+
+	switch {
+	case amount <= balance || policy == sharedTypes.ClawbackPolicyNegative:
+		return amount, nil
+	case policy == sharedTypes.ClawbackPolicyReject:
+		return 0, utils.ErrPaymentError
+	default:
+		return balance, nil
+	}
+}
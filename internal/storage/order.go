@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"strconv"
 
 	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
@@ -12,6 +13,11 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// inFlightStatuses are the statuses of orders the accrual system has not
+// settled yet. REGISTERED is the accrual system's own state before it starts
+// processing.
+const inFlightStatuses = `('NEW', 'REGISTERED', 'PROCESSING')`
+
 type Order struct {
 	Conn *pgxpool.Pool
 }
@@ -54,9 +60,93 @@ func (order *Order) CreateOrder(ctx context.Context, orderID, uid string) error
 	return nil
 }
 
+// CreateDetailedOrder stores the order together with its basket, its expected
+// accrual and, when it was pushed by a merchant, the merchant it came from.
+func (order *Order) CreateDetailedOrder(ctx context.Context, uid, merchantID string, o sharedTypes.OrderSubmission) error {
+	return pgx.BeginFunc(ctx, order.Conn, func(tx pgx.Tx) error {
+		sqlCreate := `
+		INSERT INTO orders (uid, id, status, accrual, merchant_id, total, purchased_at, expected_accrual, expected_source)
+		VALUES ($1, $2, 'NEW', 0, NULLIF($3, '')::bigint, NULLIF($4, 0), $5, NULLIF($6, 0), CASE WHEN $6 > 0 THEN $7 END)
+		ON CONFLICT (id) DO NOTHING
+		`
+
+		tag, err := tx.Exec(ctx, sqlCreate, uid, o.Number, merchantID, o.Total, o.PurchasedAt, o.ExpectedAccrual, sharedTypes.PendingSourceRules)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			var ownerID int
+
+			err = tx.QueryRow(ctx, `SELECT UID FROM orders WHERE ID = $1`, o.Number).Scan(&ownerID)
+			if err != nil {
+				return err
+			}
+
+			if strconv.Itoa(ownerID) == uid {
+				return utils.ErrAlreadyCreated
+			}
+
+			return utils.ErrDuplicate
+		}
+
+		sqlGood := `
+		INSERT INTO ORDER_GOODS (order_id, description, price, quantity)
+		VALUES ($1, $2, $3, $4)
+		`
+
+		for _, good := range o.Goods {
+			_, err = tx.Exec(ctx, sqlGood, o.Number, good.Description, good.Price, good.Quantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// CancelOrder deletes an order nobody has started processing, which frees the
+// number. Every order is registered with the accrual system before it is
+// stored and that cannot be undone, so the cancellation is always kept in the
+// order history.
+func (order *Order) CancelOrder(ctx context.Context, uid, orderID string) error {
+	return pgx.BeginFunc(ctx, order.Conn, func(tx pgx.Tx) error {
+		var status string
+
+		err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1 AND uid = $2 FOR UPDATE`, orderID, uid).Scan(&status)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if status != sharedTypes.OrderStatusNew && status != sharedTypes.OrderStatusRegistered {
+			return utils.ErrOrderLocked
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM orders WHERE id = $1`, orderID)
+		if err != nil {
+			return err
+		}
+
+		sqlHistory := `
+		INSERT INTO ORDER_HISTORY (order_id, uid, event, status)
+		VALUES ($1, $2, 'cancelled', $3)
+		`
+
+		_, err = tx.Exec(ctx, sqlHistory, orderID, uid, status)
+
+		return err
+	})
+}
+
 func (order *Order) ListOrders(ctx context.Context, uid string) ([]sharedTypes.Order, error) {
 	sqlStatement := `
-	SELECT ID, status, accrual, uploaded_at::timestamptz FROM orders WHERE UID = $1 ORDER BY uploaded_at
+	SELECT ID, status, accrual, bonus_accrual, uploaded_at::timestamptz, COALESCE(total, 0), purchased_at, net_accrual, refunded FROM orders WHERE UID = $1 ORDER BY uploaded_at
 	`
 
 	rows, err := order.Conn.Query(ctx, sqlStatement, uid)
@@ -70,7 +160,7 @@ func (order *Order) ListOrders(ctx context.Context, uid string) ([]sharedTypes.O
 
 	for rows.Next() {
 		entry := sharedTypes.Order{}
-		err = rows.Scan(&entry.Number, &entry.Status, &entry.Accrual, &entry.UploadedAt)
+		err = rows.Scan(&entry.Number, &entry.Status, &entry.Accrual, &entry.Bonus, &entry.UploadedAt, &entry.Total, &entry.PurchasedAt, &entry.NetAccrual, &entry.Refunded)
 
 		if err != nil {
 			return nil, err
@@ -89,8 +179,7 @@ func (order *Order) ListOrders(ctx context.Context, uid string) ([]sharedTypes.O
 
 func (order *Order) GetUnproccessedOrders(ctx context.Context) ([]sharedTypes.Order, error) {
 	sqlStatement := `
-	SELECT id, status, accrual, uploaded_at::timestamptz FROM orders WHERE status = 'NEW' or status = 'PROCESSING'
-	`
+	SELECT id, status, accrual, uploaded_at::timestamptz FROM orders WHERE status IN ` + inFlightStatuses
 
 	rows, err := order.Conn.Query(ctx, sqlStatement)
 	if err != nil {
@@ -120,14 +209,23 @@ func (order *Order) GetUnproccessedOrders(ctx context.Context) ([]sharedTypes.Or
 	return orders, nil
 }
 
+// UpdateOrder credits the accrual only once the order is processed. While it
+// is still in flight an accrual reported by the accrual system replaces our
+// own estimate as the expected one.
 func (order *Order) UpdateOrder(ctx context.Context, orderID, status string, accrual float32) (string, error) {
 	updateOrderSQL := `
-	UPDATE orders SET status = $1, accrual = $2  WHERE id = $3
+	UPDATE orders SET
+		status = $1,
+		accrual = CASE WHEN $1 = 'PROCESSED' THEN $2 ELSE accrual END,
+		net_accrual = CASE WHEN $1 = 'PROCESSED' THEN $2 ELSE net_accrual END,
+		expected_accrual = CASE WHEN $1 = 'PROCESSED' OR $2 = 0 THEN expected_accrual ELSE $2 END,
+		expected_source = CASE WHEN $1 = 'PROCESSED' OR $2 = 0 THEN expected_source ELSE $4 END
+	WHERE id = $3
 	returning uid;
 	`
 
 	var uid string
-	err := order.Conn.QueryRow(ctx, updateOrderSQL, status, accrual, orderID).Scan(&uid)
+	err := order.Conn.QueryRow(ctx, updateOrderSQL, status, accrual, orderID, sharedTypes.PendingSourceAccrual).Scan(&uid)
 
 	if err != nil {
 		return "", err
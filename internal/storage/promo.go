@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Promo struct {
+	Conn *pgxpool.Pool
+}
+
+func InitPromo(conn *pgxpool.Pool) (*Promo, error) {
+	return &Promo{conn}, nil
+}
+
+func (ps *Promo) CreatePromoCode(ctx context.Context, p sharedTypes.PromoCode) (sharedTypes.PromoCode, error) {
+	sqlStatement := `
+	INSERT INTO PROMO_CODES (code_hash, hint, amount, max_redemptions, per_user_limit, expires_at, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at::timestamptz
+	`
+
+	err := ps.Conn.QueryRow(ctx, sqlStatement, p.Hash, p.Hint, p.Amount, p.MaxRedemptions, p.PerUserLimit, p.ExpiresAt, p.CreatedBy).
+		Scan(&p.ID, &p.CreatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return p, utils.ErrDuplicate
+	}
+
+	return p, err
+}
+
+func (ps *Promo) ListPromoCodes(ctx context.Context) ([]sharedTypes.PromoCode, error) {
+	sqlStatement := `
+	SELECT id, hint, amount, max_redemptions, per_user_limit, redeemed, expires_at, revoked_at, created_at::timestamptz FROM PROMO_CODES
+	ORDER BY id DESC
+	`
+
+	rows, err := ps.Conn.Query(ctx, sqlStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	codes := []sharedTypes.PromoCode{}
+
+	for rows.Next() {
+		p := sharedTypes.PromoCode{}
+		err = rows.Scan(&p.ID, &p.Hint, &p.Amount, &p.MaxRedemptions, &p.PerUserLimit, &p.Redeemed, &p.ExpiresAt, &p.RevokedAt, &p.CreatedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, p)
+	}
+
+	return codes, rows.Err()
+}
+
+func (ps *Promo) RevokePromoCode(ctx context.Context, id string) (bool, error) {
+	sqlStatement := `
+	UPDATE PROMO_CODES SET revoked_at = current_timestamp
+	WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	tag, err := ps.Conn.Exec(ctx, sqlStatement, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// RedeemPromoCode claims a redemption and credits it in one transaction. The
+// counter update row-locks the code, so concurrent redemptions from any
+// instance queue up behind it and a limited code is never over-redeemed.
+func (ps *Promo) RedeemPromoCode(ctx context.Context, uid, hash string) (sharedTypes.PromoRedemption, error) {
+	redemption := sharedTypes.PromoRedemption{}
+
+	err := pgx.BeginFunc(ctx, ps.Conn, func(tx pgx.Tx) error {
+		sqlClaim := `
+		UPDATE PROMO_CODES SET redeemed = redeemed + 1
+		WHERE code_hash = $1 AND revoked_at IS NULL AND expires_at > current_timestamp
+			AND (max_redemptions = 0 OR redeemed < max_redemptions)
+		RETURNING id, amount, per_user_limit
+		`
+
+		var perUserLimit int
+		err := tx.QueryRow(ctx, sqlClaim, hash).Scan(&redemption.CodeID, &redemption.Amount, &perUserLimit)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			var exists bool
+
+			err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM PROMO_CODES WHERE code_hash = $1)`, hash).Scan(&exists)
+			if err != nil {
+				return err
+			}
+
+			if exists {
+				return utils.ErrPromoExhausted
+			}
+
+			return utils.ErrNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var used int
+
+		err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM PROMO_REDEMPTIONS WHERE code_id = $1 AND uid = $2`, redemption.CodeID, uid).Scan(&used)
+		if err != nil {
+			return err
+		}
+
+		if used >= perUserLimit {
+			return utils.ErrPromoLimit
+		}
+
+		sqlInsert := `
+		INSERT INTO PROMO_REDEMPTIONS (code_id, uid, amount)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at::timestamptz
+		`
+
+		err = tx.QueryRow(ctx, sqlInsert, redemption.CodeID, uid, redemption.Amount).Scan(&redemption.ID, &redemption.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance + $2 WHERE uid = $1`, uid, redemption.Amount)
+		if err != nil {
+			return err
+		}
+
+		return addLot(ctx, tx, uid, lotSourcePromo, redemption.ID, redemption.Amount)
+	})
+
+	return redemption, err
+}
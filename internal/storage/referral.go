@@ -0,0 +1,255 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Referral struct {
+	Conn *pgxpool.Pool
+}
+
+func InitReferral(conn *pgxpool.Pool) (*Referral, error) {
+	return &Referral{conn}, nil
+}
+
+// GetReferralCode returns an empty code for users who have not asked for one yet.
+func (rs *Referral) GetReferralCode(ctx context.Context, uid string) (string, error) {
+	var code string
+	err := rs.Conn.QueryRow(ctx, `SELECT COALESCE(referral_code, '') FROM USERS WHERE uid = $1`, uid).Scan(&code)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", utils.ErrNotFound
+	}
+
+	return code, err
+}
+
+// SetReferralCode keeps a code the user already has and returns whichever
+// code is stored. A code taken by someone else is reported as ErrDuplicate.
+func (rs *Referral) SetReferralCode(ctx context.Context, uid, code string) (string, error) {
+	sqlStatement := `
+	UPDATE USERS SET referral_code = COALESCE(referral_code, $2)
+	WHERE uid = $1
+	RETURNING referral_code
+	`
+
+	err := rs.Conn.QueryRow(ctx, sqlStatement, uid, code).Scan(&code)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return "", utils.ErrDuplicate
+	}
+
+	return code, err
+}
+
+// GetReferrer only resolves codes of accounts that can still earn rewards.
+func (rs *Referral) GetReferrer(ctx context.Context, code string) (sharedTypes.Referrer, error) {
+	sqlStatement := `
+	SELECT uid FROM USERS
+	WHERE referral_code = $1 AND deleted_at IS NULL AND merged_into IS NULL AND suspended_at IS NULL
+	`
+
+	var referrer sharedTypes.Referrer
+	err := rs.Conn.QueryRow(ctx, sqlStatement, code).Scan(&referrer.UID)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return referrer, utils.ErrReferralCode
+	}
+
+	return referrer, err
+}
+
+// CreateReferredUser registers the user and links them to the referrer in
+// one transaction, so no account is left without the referral it signed up with.
+// The referrer row is locked while the day's sign-ups are counted, so
+// concurrent sign-ups with one code cannot exceed dailyLimit between them.
+func (rs *Referral) CreateReferredUser(ctx context.Context, creds sharedTypes.Credentials, referrerUID string, dailyLimit int) (string, error) {
+	var uid string
+
+	err := pgx.BeginFunc(ctx, rs.Conn, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `SELECT uid FROM USERS WHERE uid = $1 FOR UPDATE`, referrerUID)
+		if err != nil {
+			return err
+		}
+
+		sqlToday := `
+		SELECT COUNT(*) FROM REFERRALS
+		WHERE referrer_uid = $1 AND created_at > current_timestamp - interval '1 day'
+		`
+
+		var invited int
+
+		err = tx.QueryRow(ctx, sqlToday, referrerUID).Scan(&invited)
+		if err != nil {
+			return err
+		}
+
+		if invited >= dailyLimit {
+			return utils.ErrReferralLimit
+		}
+
+		sqlUser := `
+		INSERT INTO users (login, password_hash, current_balance, withdrawn)
+		VALUES ($1, $2, 0, 0)
+		RETURNING uid;`
+
+		err = tx.QueryRow(ctx, sqlUser, creds.Login, creds.Password).Scan(&uid)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO REFERRALS (referrer_uid, referred_uid) VALUES ($1, $2)`, referrerUID, uid)
+
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return "", utils.ErrDuplicate
+	}
+
+	return uid, err
+}
+
+func (rs *Referral) ListReferrals(ctx context.Context, uid string) ([]sharedTypes.Referral, error) {
+	sqlStatement := `
+	SELECT r.id, r.referred_uid, COALESCE(u.login, ''), r.status, COALESCE(r.reason, ''), r.referrer_bonus,
+		r.created_at::timestamptz, r.closed_at
+	FROM REFERRALS r
+	JOIN USERS u ON u.uid = r.referred_uid
+	WHERE r.referrer_uid = $1
+	ORDER BY r.created_at DESC, r.id DESC
+	`
+
+	rows, err := rs.Conn.Query(ctx, sqlStatement, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	referrals := []sharedTypes.Referral{}
+
+	for rows.Next() {
+		r := sharedTypes.Referral{ReferrerUID: uid}
+		err = rows.Scan(&r.ID, &r.ReferredUID, &r.Login, &r.Status, &r.Reason, &r.ReferrerBonus, &r.CreatedAt, &r.ClosedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		referrals = append(referrals, r)
+	}
+
+	return referrals, rows.Err()
+}
+
+func (rs *Referral) GetPendingReferral(ctx context.Context, referredUID string) (sharedTypes.Referral, error) {
+	sqlStatement := `
+	SELECT id, referrer_uid, referred_uid, status, created_at::timestamptz FROM REFERRALS
+	WHERE referred_uid = $1 AND status = 'pending'
+	`
+
+	var r sharedTypes.Referral
+	err := rs.Conn.QueryRow(ctx, sqlStatement, referredUID).Scan(&r.ID, &r.ReferrerUID, &r.ReferredUID, &r.Status, &r.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return r, utils.ErrNotFound
+	}
+
+	return r, err
+}
+
+// RewardReferral credits both sides of a pending referral. The referrer's row
+// is locked while their rewards are counted, so concurrent first orders of
+// different invitees cannot push them over maxRewards. A referrer who is over
+// the cap or no longer active gets the referral rejected instead.
+func (rs *Referral) RewardReferral(ctx context.Context, r sharedTypes.Referral, maxRewards int) (sharedTypes.Referral, error) {
+	err := pgx.BeginFunc(ctx, rs.Conn, func(tx pgx.Tx) error {
+		sqlReferrer := `
+		SELECT deleted_at IS NULL AND merged_into IS NULL AND suspended_at IS NULL, (
+			SELECT COUNT(*) FROM REFERRALS WHERE referrer_uid = $1 AND status = 'rewarded'
+		) FROM USERS
+		WHERE uid = $1
+		FOR UPDATE
+		`
+
+		var active bool
+		var rewarded int
+
+		err := tx.QueryRow(ctx, sqlReferrer, r.ReferrerUID).Scan(&active, &rewarded)
+		if err != nil {
+			return err
+		}
+
+		r.Status = sharedTypes.ReferralStatusRewarded
+
+		switch {
+		case !active:
+			r.Status, r.Reason = sharedTypes.ReferralStatusRejected, sharedTypes.ReferralReasonInactive
+		case rewarded >= maxRewards:
+			r.Status, r.Reason = sharedTypes.ReferralStatusRejected, sharedTypes.ReferralReasonCapReached
+		}
+
+		if r.Status == sharedTypes.ReferralStatusRejected {
+			r.ReferrerBonus, r.RefereeBonus = 0, 0
+		}
+
+		sqlClose := `
+		UPDATE REFERRALS SET status = $2, reason = NULLIF($3, ''), order_id = $4, referrer_bonus = $5, referee_bonus = $6,
+			closed_at = current_timestamp
+		WHERE id = $1 AND status = 'pending'
+		RETURNING closed_at
+		`
+
+		err = tx.QueryRow(ctx, sqlClose, r.ID, r.Status, r.Reason, r.OrderID, r.ReferrerBonus, r.RefereeBonus).Scan(&r.ClosedAt)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrNotFound
+		}
+
+		if err != nil || r.Status != sharedTypes.ReferralStatusRewarded {
+			return err
+		}
+
+		for uid, amount := range map[string]float32{r.ReferrerUID: r.ReferrerBonus, r.ReferredUID: r.RefereeBonus} {
+			if amount <= 0 {
+				continue
+			}
+
+			_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance + $2 WHERE uid = $1`, uid, amount)
+			if err != nil {
+				return err
+			}
+
+			err = addLot(ctx, tx, uid, lotSourceReferral, r.ID, amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return r, err
+}
+
+func (rs *Referral) RejectReferral(ctx context.Context, id, reason string) error {
+	sqlStatement := `
+	UPDATE REFERRALS SET status = 'rejected', reason = $2, closed_at = current_timestamp
+	WHERE id = $1 AND status = 'pending'
+	`
+
+	_, err := rs.Conn.Exec(ctx, sqlStatement, id, reason)
+
+	return err
+}
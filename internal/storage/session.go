@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Session struct {
+	Conn *pgxpool.Pool
+}
+
+func InitSession(conn *pgxpool.Pool) (*Session, error) {
+	return &Session{conn}, nil
+}
+
+func (s *Session) CreateSession(ctx context.Context, uid string, client sharedTypes.Client, ttlHours int64) (string, error) {
+	sqlStatement := `
+	INSERT INTO SESSIONS (uid, ip, user_agent, expires_at)
+	VALUES ($1, $2, $3, current_timestamp + make_interval(hours => $4::integer))
+	RETURNING id;
+	`
+
+	var id string
+	err := s.Conn.QueryRow(ctx, sqlStatement, uid, client.IP, client.UserAgent, ttlHours).Scan(&id)
+
+	return id, err
+}
+
+func (s *Session) ListSessions(ctx context.Context, uid string) ([]sharedTypes.Session, error) {
+	sqlStatement := `
+	SELECT id, COALESCE(ip, ''), COALESCE(user_agent, ''), created_at::timestamptz, last_seen_at::timestamptz FROM SESSIONS
+	WHERE uid = $1 AND revoked_at IS NULL AND expires_at > current_timestamp
+	ORDER BY last_seen_at DESC
+	`
+
+	rows, err := s.Conn.Query(ctx, sqlStatement, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sessions := []sharedTypes.Session{}
+
+	for rows.Next() {
+		entry := sharedTypes.Session{}
+		err = rows.Scan(&entry.ID, &entry.IP, &entry.UserAgent, &entry.CreatedAt, &entry.LastSeenAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *Session) RevokeSession(ctx context.Context, uid, sid string) (bool, error) {
+	sqlStatement := `
+	UPDATE SESSIONS SET revoked_at = current_timestamp
+	WHERE id = $1 AND uid = $2 AND revoked_at IS NULL
+	`
+
+	tag, err := s.Conn.Exec(ctx, sqlStatement, sid, uid)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *Session) RevokeUserSessions(ctx context.Context, uid string) error {
+	sqlStatement := `
+	UPDATE SESSIONS SET revoked_at = current_timestamp
+	WHERE uid = $1 AND revoked_at IS NULL
+	`
+
+	_, err := s.Conn.Exec(ctx, sqlStatement, uid)
+
+	return err
+}
+
+// TouchSession reports whether the session is still active and its owner not
+// suspended, along with the owner's current role so a demotion applies to
+// tokens already issued; last_seen_at is refreshed at most once a minute to
+// keep authenticated reads cheap.
+func (s *Session) TouchSession(ctx context.Context, sid, uid string) (string, bool, error) {
+	sqlStatement := `
+	WITH active AS (
+		SELECT s.id, s.last_seen_at, u.role FROM SESSIONS s
+		JOIN USERS u ON u.uid = s.uid
+		WHERE s.id = $1 AND s.uid = $2 AND s.revoked_at IS NULL AND s.expires_at > current_timestamp
+		AND u.suspended_at IS NULL
+	), touched AS (
+		UPDATE SESSIONS SET last_seen_at = current_timestamp
+		WHERE id IN (SELECT id FROM active WHERE last_seen_at < current_timestamp - interval '1 minute')
+	)
+	SELECT role FROM active
+	`
+
+	var role string
+	err := s.Conn.QueryRow(ctx, sqlStatement, sid, uid).Scan(&role)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return role, true, nil
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// tierMemberColumns selects a user's tier together with the base accrual they
+// still hold from orders processed within the last twelve months. Bonus points
+// and clawed back points do not count towards a tier.
+const tierMemberColumns = `
+	u.uid, u.tier, u.tier_since::timestamptz,
+	COALESCE((
+		SELECT SUM(GREATEST(o.net_accrual - o.bonus_accrual, 0)) FROM ORDERS o
+		WHERE o.uid = u.uid AND o.status = 'PROCESSED' AND o.uploaded_at > current_timestamp - interval '12 months'
+	), 0)
+`
+
+type Tier struct {
+	Conn *pgxpool.Pool
+}
+
+func InitTier(conn *pgxpool.Pool) (*Tier, error) {
+	return &Tier{conn}, nil
+}
+
+func (t *Tier) ListTiers(ctx context.Context) ([]sharedTypes.Tier, error) {
+	rows, err := t.Conn.Query(ctx, `SELECT name, min_accrual, multiplier FROM TIERS ORDER BY min_accrual`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tiers := []sharedTypes.Tier{}
+
+	for rows.Next() {
+		entry := sharedTypes.Tier{}
+		err = rows.Scan(&entry.Name, &entry.MinAccrual, &entry.Multiplier)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tiers = append(tiers, entry)
+	}
+
+	return tiers, rows.Err()
+}
+
+func (t *Tier) GetTierMember(ctx context.Context, uid string) (sharedTypes.TierMember, error) {
+	sqlStatement := `SELECT ` + tierMemberColumns + ` FROM USERS u WHERE u.uid = $1 AND u.deleted_at IS NULL`
+
+	var m sharedTypes.TierMember
+	err := t.Conn.QueryRow(ctx, sqlStatement, uid).Scan(&m.UID, &m.Tier, &m.Since, &m.RollingAccrual)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return m, utils.ErrNotFound
+	}
+
+	return m, err
+}
+
+// ListTierMembers pages through active users in uid order.
+func (t *Tier) ListTierMembers(ctx context.Context, afterUID string, limit int) ([]sharedTypes.TierMember, error) {
+	sqlStatement := `
+	SELECT ` + tierMemberColumns + ` FROM USERS u
+	WHERE u.uid > $1 AND u.deleted_at IS NULL
+	ORDER BY u.uid
+	LIMIT $2
+	`
+
+	rows, err := t.Conn.Query(ctx, sqlStatement, afterUID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	members := []sharedTypes.TierMember{}
+
+	for rows.Next() {
+		entry := sharedTypes.TierMember{}
+		err = rows.Scan(&entry.UID, &entry.Tier, &entry.Since, &entry.RollingAccrual)
+
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, entry)
+	}
+
+	return members, rows.Err()
+}
+
+func (t *Tier) SetTier(ctx context.Context, uid, tier string) error {
+	_, err := t.Conn.Exec(ctx, `UPDATE USERS SET tier = $2, tier_since = current_timestamp WHERE uid = $1`, uid, tier)
+
+	return err
+}
+
+func (t *Tier) GetMultiplier(ctx context.Context, uid string) (float32, error) {
+	sqlStatement := `
+	SELECT t.multiplier FROM USERS u
+	JOIN TIERS t ON t.name = u.tier
+	WHERE u.uid = $1
+	`
+
+	var multiplier float32
+	err := t.Conn.QueryRow(ctx, sqlStatement, uid).Scan(&multiplier)
+
+	return multiplier, err
+}
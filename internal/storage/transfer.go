@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"github.com/T-V-N/gopherstore/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Transfer struct {
+	Conn *pgxpool.Pool
+}
+
+func InitTransfer(conn *pgxpool.Pool) (*Transfer, error) {
+	return &Transfer{conn}, nil
+}
+
+// GetRecipient only resolves accounts that are still in use.
+func (ts *Transfer) GetRecipient(ctx context.Context, login string) (string, error) {
+	sqlStatement := `
+	SELECT uid FROM USERS
+	WHERE login = $1 AND deleted_at IS NULL AND merged_into IS NULL AND suspended_at IS NULL
+	`
+
+	var uid string
+	err := ts.Conn.QueryRow(ctx, sqlStatement, login).Scan(&uid)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", utils.ErrNotFound
+	}
+
+	return uid, err
+}
+
+// TransferPoints moves points in one transaction. Both rows are locked in uid
+// order before the balance and the daily limits are checked, and the sender is
+// debited through the same guarded debit as withdrawals, so neither transfers
+// nor withdrawals running on other instances can overdraw the sender.
+func (ts *Transfer) TransferPoints(ctx context.Context, t sharedTypes.Transfer, limits sharedTypes.TransferLimits) (sharedTypes.Transfer, error) {
+	err := pgx.BeginFunc(ctx, ts.Conn, func(tx pgx.Tx) error {
+		sqlLock := `
+		SELECT uid, current_balance, frozen_at IS NOT NULL, deleted_at IS NULL AND merged_into IS NULL AND suspended_at IS NULL FROM USERS
+		WHERE uid IN ($1, $2)
+		ORDER BY uid
+		FOR UPDATE
+		`
+
+		rows, err := tx.Query(ctx, sqlLock, t.SenderUID, t.RecipientUID)
+		if err != nil {
+			return err
+		}
+
+		var balance float32
+		var frozen, recipientActive bool
+
+		for rows.Next() {
+			var uid string
+			var current float32
+			var isFrozen, active bool
+
+			err = rows.Scan(&uid, &current, &isFrozen, &active)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			if uid == t.SenderUID {
+				balance, frozen = current, isFrozen
+			} else {
+				recipientActive = active
+			}
+		}
+
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		switch {
+		case !recipientActive:
+			return utils.ErrNotFound
+		case frozen:
+			return utils.ErrFrozen
+		case balance-t.Amount < 0:
+			return utils.ErrPaymentError
+		}
+
+		sqlSent := `
+		SELECT COUNT(*), COALESCE(SUM(amount), 0) FROM POINT_TRANSFERS
+		WHERE sender_uid = $1 AND created_at > current_timestamp - interval '1 day'
+		`
+
+		var count int
+		var sent float32
+
+		err = tx.QueryRow(ctx, sqlSent, t.SenderUID).Scan(&count, &sent)
+		if err != nil {
+			return err
+		}
+
+		if count >= limits.Count || sent+t.Amount > limits.Amount {
+			return utils.ErrTransferLimit
+		}
+
+		sqlInsert := `
+		INSERT INTO POINT_TRANSFERS (sender_uid, recipient_uid, amount)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at::timestamptz
+		`
+
+		err = tx.QueryRow(ctx, sqlInsert, t.SenderUID, t.RecipientUID, t.Amount).Scan(&t.ID, &t.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		err = debitBalance(ctx, tx, t.SenderUID, t.Amount)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE USERS SET current_balance = current_balance + $2 WHERE uid = $1`, t.RecipientUID, t.Amount)
+		if err != nil {
+			return err
+		}
+
+		return moveLots(ctx, tx, t.SenderUID, t.RecipientUID, t.ID, t.Amount)
+	})
+
+	return t, err
+}
+
+// ListTransfers returns what the user sent and received, newest first.
+func (ts *Transfer) ListTransfers(ctx context.Context, uid string) ([]sharedTypes.Transfer, error) {
+	return listTransfers(ctx, ts.Conn, uid)
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+func listTransfers(ctx context.Context, q querier, uid string) ([]sharedTypes.Transfer, error) {
+	sqlStatement := `
+	SELECT t.id, t.sender_uid, t.recipient_uid, t.amount, t.created_at::timestamptz, COALESCE(u.login, '') FROM POINT_TRANSFERS t
+	JOIN USERS u ON u.uid = CASE WHEN t.sender_uid = $1 THEN t.recipient_uid ELSE t.sender_uid END
+	WHERE t.sender_uid = $1 OR t.recipient_uid = $1
+	ORDER BY t.created_at DESC, t.id DESC
+	`
+
+	rows, err := q.Query(ctx, sqlStatement, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	transfers := []sharedTypes.Transfer{}
+
+	for rows.Next() {
+		t := sharedTypes.Transfer{}
+		err = rows.Scan(&t.ID, &t.SenderUID, &t.RecipientUID, &t.Amount, &t.CreatedAt, &t.Login)
+
+		if err != nil {
+			return nil, err
+		}
+
+		t.Direction = sharedTypes.TransferReceived
+		if t.SenderUID == uid {
+			t.Direction = sharedTypes.TransferSent
+		}
+
+		transfers = append(transfers, t)
+	}
+
+	return transfers, rows.Err()
+}
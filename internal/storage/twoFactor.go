@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (user *User) SetTOTPSecret(ctx context.Context, uid, secret string) error {
+	sqlStatement := `
+	UPDATE USERS SET totp_secret = $1
+	WHERE uid = $2 AND NOT totp_enabled
+	`
+
+	_, err := user.Conn.Exec(ctx, sqlStatement, secret, uid)
+
+	return err
+}
+
+func (user *User) EnableTOTP(ctx context.Context, uid string, step int64, codeHashes []string) error {
+	return pgx.BeginFunc(ctx, user.Conn, func(tx pgx.Tx) error {
+		sqlEnable := `
+		UPDATE USERS SET totp_enabled = true, totp_last_step = $1
+		WHERE uid = $2
+		`
+
+		_, err := tx.Exec(ctx, sqlEnable, step, uid)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM RECOVERY_CODES WHERE uid = $1`, uid)
+		if err != nil {
+			return err
+		}
+
+		for _, hash := range codeHashes {
+			_, err = tx.Exec(ctx, `INSERT INTO RECOVERY_CODES (uid, code_hash) VALUES ($1, $2)`, uid, hash)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (user *User) DisableTOTP(ctx context.Context, uid string) error {
+	return pgx.BeginFunc(ctx, user.Conn, func(tx pgx.Tx) error {
+		sqlDisable := `
+		UPDATE USERS SET totp_enabled = false, totp_secret = NULL, totp_last_step = 0
+		WHERE uid = $1
+		`
+
+		_, err := tx.Exec(ctx, sqlDisable, uid)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM RECOVERY_CODES WHERE uid = $1`, uid)
+
+		return err
+	})
+}
+
+// UseTOTPStep moves the last used step forward; false means the step was already used.
+func (user *User) UseTOTPStep(ctx context.Context, uid string, step int64) (bool, error) {
+	sqlStatement := `
+	UPDATE USERS SET totp_last_step = $1
+	WHERE uid = $2 AND totp_last_step < $1
+	`
+
+	tag, err := user.Conn.Exec(ctx, sqlStatement, step, uid)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (user *User) UseRecoveryCode(ctx context.Context, uid, codeHash string) (bool, error) {
+	sqlStatement := `
+	UPDATE RECOVERY_CODES SET used_at = current_timestamp
+	WHERE uid = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	tag, err := user.Conn.Exec(ctx, sqlStatement, uid, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
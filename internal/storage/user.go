@@ -9,6 +9,7 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -38,29 +39,102 @@ func (user *User) CreateUser(ctx context.Context, creds sharedTypes.Credentials)
 	return id, nil
 }
 
-func (user *User) UpdateUser(ctx context.Context, uid, orderID string, accrual float32) error {
+// UpdateUser credits an order's accrual together with the tier bonus paid on
+// top of it, recording the bonus on the order in the same transaction.
+// Household members earn into the shared pool, and the order remembers which
+// pool it went to.
+func (user *User) UpdateUser(ctx context.Context, uid, orderID string, accrual, bonus float32) error {
+	return pgx.BeginFunc(ctx, user.Conn, func(tx pgx.Tx) error {
+		if bonus > 0 {
+			_, err := tx.Exec(ctx, `UPDATE orders SET bonus_accrual = $2, net_accrual = net_accrual + $2 WHERE id = $1`, orderID, bonus)
+			if err != nil {
+				return err
+			}
+
+			accrual += bonus
+		}
+
+		return creditAccrual(ctx, tx, uid, orderID, accrual)
+	})
+}
+
+// creditAccrual credits an order's accrual to the user's household pool, or to
+// the user when they have none, and marks the order with the pool it went to.
+func creditAccrual(ctx context.Context, tx pgx.Tx, uid, orderID string, accrual float32) error {
+	poolSQL := `
+	UPDATE HOUSEHOLDS h SET balance = balance + $1
+	FROM HOUSEHOLD_MEMBERS m
+	WHERE m.uid = $2 AND h.id = m.household_id
+	RETURNING h.id
+	`
+
+	var householdID string
+	err := tx.QueryRow(ctx, poolSQL, accrual, uid).Scan(&householdID)
+
+	if err == nil {
+		_, err = tx.Exec(ctx, `UPDATE ORDERS SET household_id = $2 WHERE id = $1`, orderID, householdID)
+		if err != nil {
+			return err
+		}
+
+		return addPoolLot(ctx, tx, householdID, uid, lotSourceOrder, orderID, accrual)
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
 	updateBalanceSQL := `
 	UPDATE USERS SET current_balance = current_balance + $1
-	WHERE uid = (select uid from orders WHERE id = $2)
+	WHERE uid = $2
 	`
 
-	_, err := user.Conn.Exec(ctx, updateBalanceSQL, accrual, orderID)
+	_, err = tx.Exec(ctx, updateBalanceSQL, accrual, uid)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return addLot(ctx, tx, uid, lotSourceOrder, orderID, accrual)
+}
+
+func (user *User) UpdatePasswordHash(ctx context.Context, uid, hash string) error {
+	sqlStatement := `
+	UPDATE USERS SET password_hash = $1
+	WHERE uid = $2
+	`
+
+	_, err := user.Conn.Exec(ctx, sqlStatement, hash, uid)
+
+	return err
 }
 
 func (user *User) GetUser(ctx context.Context, creds sharedTypes.Credentials) (sharedTypes.User, error) {
 	sqlStatement := `
-	SELECT uid, login, password_hash, current_balance, withdrawn FROM USERS
+	SELECT uid, login, password_hash, current_balance, withdrawn, COALESCE(totp_secret, ''), totp_enabled, totp_last_step, role,
+	suspended_at IS NOT NULL, frozen_at IS NOT NULL FROM USERS
 	WHERE login = $1
 	`
 
 	var u sharedTypes.User
-	err := user.Conn.QueryRow(ctx, sqlStatement, creds.Login).Scan(&u.UID, &u.Login, &u.PasswordHash, &u.CurrentBalance, &u.Withdrawn)
+	err := user.Conn.QueryRow(ctx, sqlStatement, creds.Login).Scan(&u.UID, &u.Login, &u.PasswordHash, &u.CurrentBalance, &u.Withdrawn, &u.TOTPSecret, &u.TOTPEnabled, &u.TOTPLastStep, &u.Role, &u.Suspended, &u.Frozen)
+
+	if err != nil {
+		return u, err
+	}
+
+	return u, nil
+}
+
+func (user *User) GetUserByID(ctx context.Context, uid string) (sharedTypes.User, error) {
+	sqlStatement := `
+	SELECT uid, login, password_hash, current_balance, withdrawn, COALESCE(totp_secret, ''), totp_enabled, totp_last_step, role,
+	suspended_at IS NOT NULL, frozen_at IS NOT NULL FROM USERS
+	WHERE uid = $1 AND deleted_at IS NULL
+	`
+
+	var u sharedTypes.User
+	err := user.Conn.QueryRow(ctx, sqlStatement, uid).Scan(&u.UID, &u.Login, &u.PasswordHash, &u.CurrentBalance, &u.Withdrawn, &u.TOTPSecret, &u.TOTPEnabled, &u.TOTPLastStep, &u.Role, &u.Suspended, &u.Frozen)
 
 	if err != nil {
 		return u, err
@@ -71,32 +145,121 @@ func (user *User) GetUser(ctx context.Context, creds sharedTypes.Credentials) (s
 
 func (user *User) GetBalance(ctx context.Context, uid string) (sharedTypes.Balance, error) {
 	sqlStatement := `
-	SELECT uid, login, password_hash, current_balance, withdrawn FROM USERS
+	SELECT uid, login, password_hash, current_balance, withdrawn, frozen_at IS NOT NULL,
+		(SELECT COALESCE(SUM(expected_accrual), 0) FROM orders WHERE uid = USERS.uid AND status IN ` + inFlightStatuses + `)
+	FROM USERS
 	WHERE uid = $1
 	`
 
-	var u sharedTypes.User
-	err := user.Conn.QueryRow(ctx, sqlStatement, uid).Scan(&u.UID, &u.Login, &u.PasswordHash, &u.CurrentBalance, &u.Withdrawn)
+	var (
+		u       sharedTypes.User
+		pending float32
+	)
+
+	err := user.Conn.QueryRow(ctx, sqlStatement, uid).Scan(&u.UID, &u.Login, &u.PasswordHash, &u.CurrentBalance, &u.Withdrawn, &u.Frozen, &pending)
 
 	if err != nil {
 		return sharedTypes.Balance{}, err
 	}
 
-	return sharedTypes.Balance{Current: u.CurrentBalance, Withdrawn: u.Withdrawn}, nil
+	return sharedTypes.Balance{Current: u.CurrentBalance, Withdrawn: u.Withdrawn, Pending: pending, Frozen: u.Frozen}, nil
 }
 
-func (user *User) WithdrawBalance(ctx context.Context, uid, orderID string, amount, newCurrent, newWithdrawn float32, withdrawal sharedTypes.WithdrawalStorager) error {
-	sqlUpdateUser := `	
-	UPDATE USERS
-    SET current_balance = $1, withdrawn = $2
-	WHERE uid = $3;
+func (user *User) ListPendingAccruals(ctx context.Context, uid string) ([]sharedTypes.PendingAccrual, error) {
+	sqlStatement := `
+	SELECT id, status, expected_accrual, COALESCE(expected_source, ''), uploaded_at::timestamptz FROM orders
+	WHERE uid = $1 AND expected_accrual IS NOT NULL AND status IN ` + inFlightStatuses + `
+	ORDER BY uploaded_at
 	`
 
-	_, err := user.Conn.Exec(ctx, sqlUpdateUser, newCurrent, newWithdrawn, uid)
+	rows, err := user.Conn.Query(ctx, sqlStatement, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := []sharedTypes.PendingAccrual{}
+
+	for rows.Next() {
+		entry := sharedTypes.PendingAccrual{}
+		err = rows.Scan(&entry.Number, &entry.Status, &entry.Accrual, &entry.Source, &entry.UploadedAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, entry)
+	}
+
+	return list, rows.Err()
+}
+
+// WithdrawBalance debits the balance, records the withdrawal and spends the
+// lots in one transaction, so a failure part way leaves nothing behind.
+func (user *User) WithdrawBalance(ctx context.Context, uid, orderID string, amount float32) (sharedTypes.Balance, error) {
+	balance := sharedTypes.Balance{}
+
+	err := pgx.BeginFunc(ctx, user.Conn, func(tx pgx.Tx) error {
+		err := debitBalance(ctx, tx, uid, amount)
+		if err != nil {
+			return err
+		}
+
+		sqlWithdrawn := `UPDATE USERS SET withdrawn = withdrawn + $2 WHERE uid = $1 RETURNING current_balance, withdrawn`
+
+		err = tx.QueryRow(ctx, sqlWithdrawn, uid, amount).Scan(&balance.Current, &balance.Withdrawn)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO WITHDRAWALS (id, sum, uid) VALUES ($1, $2, $3)`, orderID, amount, uid)
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return utils.ErrDuplicate
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return consumeLots(ctx, tx, uid, amount)
+	})
+
+	return balance, err
+}
+
+// debitBalance takes points off a user's balance under a row lock. The update
+// is relative and guarded, so it never writes back a balance computed before
+// another instance changed it and never takes the balance below zero.
+func debitBalance(ctx context.Context, tx pgx.Tx, uid string, amount float32) error {
+	var frozen bool
+
+	err := tx.QueryRow(ctx, `SELECT frozen_at IS NOT NULL FROM USERS WHERE uid = $1 FOR UPDATE`, uid).Scan(&frozen)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return utils.ErrNotFound
+	}
 
 	if err != nil {
 		return err
 	}
 
-	return withdrawal.CreateWithdrawal(ctx, uid, amount, orderID)
+	if frozen {
+		return utils.ErrFrozen
+	}
+
+	sqlDebit := `UPDATE USERS SET current_balance = current_balance - $2 WHERE uid = $1 AND current_balance >= $2`
+
+	tag, err := tx.Exec(ctx, sqlDebit, uid, amount)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return utils.ErrPaymentError
+	}
+
+	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
 )
 
 type Accrual struct {
@@ -13,7 +15,13 @@ type Accrual struct {
 }
 
 type OrderID struct {
-	Order string `json:"order"`
+	Order string        `json:"order"`
+	Goods []AccrualGood `json:"goods,omitempty"`
+}
+
+type AccrualGood struct {
+	Description string  `json:"description"`
+	Price       float32 `json:"price"`
 }
 
 type AccrualOrder struct {
@@ -22,15 +30,29 @@ type AccrualOrder struct {
 	Accrual float32 `json:"accrual"`
 }
 
-func (a Accrual) RegisterOrder(ctx context.Context, orderID string) error {
+// RegisterOrder sends the basket along when there is one; the accrual system
+// prices each line, so quantities are folded into the line price.
+func (a Accrual) RegisterOrder(ctx context.Context, orderID string, goods []sharedTypes.Good) error {
 	body := bytes.NewBuffer([]byte{})
 
-	err := json.NewEncoder(body).Encode(OrderID{Order: orderID})
+	order := OrderID{Order: orderID}
+	for _, good := range goods {
+		order.Goods = append(order.Goods, AccrualGood{Description: good.Description, Price: good.Price * float32(good.Quantity)})
+	}
+
+	err := json.NewEncoder(body).Encode(order)
 	if err != nil {
 		return err
 	}
 
-	r, err := http.Post(a.url, "application/json", body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	r, err := http.DefaultClient.Do(req)
 
 	if err != nil {
 		return err
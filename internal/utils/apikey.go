@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	apiKeyTag         = "gm"
+	merchantKeyTag    = "mk"
+	apiKeyPrefixSize  = 6
+	apiKeySecretBytes = 32
+)
+
+// GenerateAPIKey returns the plaintext key shown to the partner once and the
+// public prefix used to look it up.
+func GenerateAPIKey() (key, prefix string, err error) {
+	return generateKey(apiKeyTag)
+}
+
+func GenerateMerchantKey() (key, prefix string, err error) {
+	return generateKey(merchantKeyTag)
+}
+
+func generateKey(tag string) (key, prefix string, err error) {
+	raw := make([]byte, apiKeyPrefixSize)
+
+	_, err = rand.Read(raw)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+
+	_, err = rand.Read(secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix = hex.EncodeToString(raw)
+	key = tag + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	return key, prefix, nil
+}
+
+func APIKeyPrefix(key string) (string, bool) {
+	return keyPrefix(key, apiKeyTag)
+}
+
+func MerchantKeyPrefix(key string) (string, bool) {
+	return keyPrefix(key, merchantKeyTag)
+}
+
+func keyPrefix(key, tag string) (string, bool) {
+	parts := strings.SplitN(key, "_", 3)
+
+	if len(parts) != 3 || parts[0] != tag || len(parts[1]) != apiKeyPrefixSize*2 || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+// HashAPIKey can be a plain digest: keys carry 256 bits of randomness, so
+// there is nothing for a slow hash to protect.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
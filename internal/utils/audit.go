@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+)
+
+// AuditHash chains an entry to its predecessor. Fields are length-prefixed so
+// that moving bytes between neighbouring fields changes the digest.
+func AuditHash(e sharedTypes.AuditEntry) string {
+	h := sha256.New()
+
+	fields := []string{
+		e.PrevHash,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		e.Actor,
+		e.Action,
+		e.Target,
+		e.RequestID,
+		e.IP,
+		string(e.Before),
+		string(e.After),
+	}
+
+	for _, f := range fields {
+		fmt.Fprintf(h, "%d:%s", len(f), f)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -1,6 +1,10 @@
 package utils
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+	"time"
+)
 
 var (
 	ErrAuth           = &APIError{Status: http.StatusUnauthorized, msg: "invalid auth token"}
@@ -8,10 +12,39 @@ var (
 	ErrDuplicate      = &APIError{Status: http.StatusConflict, msg: "duplicate"}
 	ErrBadCredentials = &APIError{Status: http.StatusBadRequest, msg: "login and pass must have more than 5 symbols"}
 	ErrNotAuthorized  = &APIError{Status: http.StatusUnauthorized, msg: "not authorized"}
+	ErrForbidden      = &APIError{Status: http.StatusForbidden, msg: "forbidden"}
 	ErrAlreadyCreated = &APIError{Status: http.StatusOK, msg: "entity already created"}
 	ErrWrongFormat    = &APIError{Status: http.StatusUnprocessableEntity, msg: "entity provided has unproccessable format"}
 	ErrNoData         = &APIError{Status: http.StatusNoContent, msg: "no data"}
 	ErrPaymentError   = &APIError{Status: http.StatusPaymentRequired, msg: "not enough money to spend"}
+	ErrBusy           = &APIError{Status: http.StatusServiceUnavailable, msg: "server is busy, try again later"}
+	ErrTooManyTries   = &APIError{Status: http.StatusTooManyRequests, msg: "too many attempts, try again later"}
+	ErrTwoFactorOn    = &APIError{Status: http.StatusConflict, msg: "two-factor authentication is already enabled"}
+	ErrTwoFactorOff   = &APIError{Status: http.StatusConflict, msg: "two-factor authentication is not enabled"}
+	ErrInvalidCode    = &APIError{Status: http.StatusUnprocessableEntity, msg: "invalid verification code"}
+	ErrSuspended      = &APIError{Status: http.StatusForbidden, msg: "account is suspended"}
+	ErrFrozen         = &APIError{Status: http.StatusForbidden, msg: "redemptions are frozen for this account"}
+	ErrMerged         = &APIError{Status: http.StatusConflict, msg: "account has been merged or deleted"}
+	ErrOrderLocked    = &APIError{Status: http.StatusConflict, msg: "order is already being processed"}
+	ErrDisputeClosed  = &APIError{Status: http.StatusConflict, msg: "dispute is already resolved"}
+	ErrDisputeStale   = &APIError{Status: http.StatusConflict, msg: "order changed owner since the dispute was opened"}
+	ErrNotProcessed   = &APIError{Status: http.StatusConflict, msg: "order has not been processed yet"}
+	ErrRefunded       = &APIError{Status: http.StatusConflict, msg: "order is already fully refunded"}
+	ErrPromoExhausted = &APIError{Status: http.StatusGone, msg: "promo code has expired or been used up"}
+	ErrPromoLimit     = &APIError{Status: http.StatusConflict, msg: "promo code redemption limit reached"}
+	ErrReferralCode   = &APIError{Status: http.StatusUnprocessableEntity, msg: "referral code is not valid"}
+	ErrReferralLimit  = &APIError{Status: http.StatusTooManyRequests, msg: "referral code has reached its daily sign-up limit"}
+	ErrTransferLimit  = &APIError{Status: http.StatusTooManyRequests, msg: "daily transfer limit reached"}
+	ErrHouseholdTaken = &APIError{Status: http.StatusConflict, msg: "user already belongs to a household"}
+	ErrHouseholdOwner = &APIError{Status: http.StatusConflict, msg: "household owner must dissolve the household first"}
+	ErrSpendLimit     = &APIError{Status: http.StatusForbidden, msg: "household spending limit reached"}
+)
+
+var (
+	ErrPasswordTooShort     = WrapError(errors.New("password is too short"), ErrBadCredentials)
+	ErrPasswordTooLong      = WrapError(errors.New("password is too long"), ErrBadCredentials)
+	ErrPasswordBreached     = WrapError(errors.New("password is known to be breached"), ErrBadCredentials)
+	ErrPasswordMatchesLogin = WrapError(errors.New("password must differ from login"), ErrBadCredentials)
 )
 
 type APIError struct {
@@ -39,3 +72,19 @@ func (we WrappedAPIError) Is(err error) bool {
 func WrapError(err error, APIError *APIError) error {
 	return WrappedAPIError{error: err, APIError: APIError}
 }
+
+type RetryAfterError struct {
+	RetryAfter time.Duration
+}
+
+func (e RetryAfterError) Error() string {
+	return ErrTooManyTries.Error()
+}
+
+func (e RetryAfterError) Is(err error) bool {
+	return err == ErrTooManyTries
+}
+
+func NewRetryAfterError(d time.Duration) error {
+	return RetryAfterError{RetryAfter: d}
+}
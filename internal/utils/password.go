@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/T-V-N/gopherstore/internal/config"
+	sharedTypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var errMalformedHash = errors.New("malformed password hash")
+
+type PasswordPolicy struct {
+	breached  map[string]struct{}
+	minLength int
+	maxLength int
+}
+
+func InitPasswordPolicy(cfg *config.Config) (*PasswordPolicy, error) {
+	policy := &PasswordPolicy{
+		breached:  map[string]struct{}{},
+		minLength: cfg.PasswordMinLength,
+		maxLength: cfg.PasswordMaxLength,
+	}
+
+	if cfg.BreachedPasswordList == "" {
+		return policy, nil
+	}
+
+	f, err := os.Open(cfg.BreachedPasswordList)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line != "" {
+			policy.breached[line] = struct{}{}
+		}
+	}
+
+	return policy, scanner.Err()
+}
+
+func (p *PasswordPolicy) Validate(creds sharedTypes.Credentials) error {
+	length := utf8.RuneCountInString(creds.Password)
+
+	if length < p.minLength {
+		return ErrPasswordTooShort
+	}
+
+	if p.maxLength > 0 && length > p.maxLength {
+		return ErrPasswordTooLong
+	}
+
+	if strings.EqualFold(creds.Login, creds.Password) {
+		return ErrPasswordMatchesLogin
+	}
+
+	if _, ok := p.breached[creds.Password]; ok {
+		return ErrPasswordBreached
+	}
+
+	return nil
+}
+
+type argon2Params struct {
+	time       uint32
+	memory     uint32
+	keyLength  uint32
+	saltLength uint32
+	threads    uint8
+}
+
+type PasswordHasher struct {
+	sem    chan struct{}
+	params argon2Params
+	dummy  string
+}
+
+func InitPasswordHasher(cfg *config.Config) (*PasswordHasher, error) {
+	concurrency := cfg.HashConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	h := &PasswordHasher{
+		sem: make(chan struct{}, concurrency),
+		params: argon2Params{
+			time:       cfg.Argon2Time,
+			memory:     cfg.Argon2Memory,
+			keyLength:  cfg.Argon2KeyLength,
+			saltLength: cfg.Argon2SaltLength,
+			threads:    cfg.Argon2Threads,
+		},
+	}
+
+	dummy, err := h.hash("dummy password")
+	if err != nil {
+		return nil, err
+	}
+
+	h.dummy = dummy
+
+	return h, nil
+}
+
+func (h *PasswordHasher) acquire(ctx context.Context) error {
+	select {
+	case h.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ErrBusy
+	}
+}
+
+func (h *PasswordHasher) release() {
+	<-h.sem
+}
+
+func (h *PasswordHasher) Hash(ctx context.Context, password string) (string, error) {
+	err := h.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer h.release()
+
+	return h.hash(password)
+}
+
+// needsRehash is set for valid bcrypt hashes and argon2id hashes with outdated params
+func (h *PasswordHasher) Verify(ctx context.Context, password, hash string) (ok, needsRehash bool, err error) {
+	err = h.acquire(ctx)
+	if err != nil {
+		return false, false, err
+	}
+
+	defer h.release()
+
+	if strings.HasPrefix(hash, "$2") {
+		err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		return err == nil, err == nil, nil
+	}
+
+	params, salt, key, err := decodeArgon2Hash(hash)
+	if err != nil {
+		return false, false, err
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLength)
+
+	if subtle.ConstantTimeCompare(key, computed) != 1 {
+		return false, false, nil
+	}
+
+	return true, params != h.params, nil
+}
+
+// VerifyDummy keeps response time for unknown logins close to a real check
+func (h *PasswordHasher) VerifyDummy(ctx context.Context, password string) error {
+	_, _, err := h.Verify(ctx, password, h.dummy)
+	return err
+}
+
+func (h *PasswordHasher) hash(password string) (string, error) {
+	salt := make([]byte, h.params.saltLength)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.time, h.params.memory, h.params.threads, h.params.keyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.memory,
+		h.params.time,
+		h.params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func decodeArgon2Hash(hash string) (params argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errMalformedHash
+	}
+
+	var version int
+
+	_, err = fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return params, nil, nil, errMalformedHash
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads)
+	if err != nil {
+		return params, nil, nil, errMalformedHash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errMalformedHash
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, errMalformedHash
+	}
+
+	params.saltLength = uint32(len(salt))
+	params.keyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+// promoAlphabet leaves out characters that are easy to mistype.
+const (
+	promoAlphabet      = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	promoCodeGroups    = 3
+	referralCodeGroups = 2
+	promoGroupSize     = 4
+	promoHintSize      = 4
+)
+
+func GeneratePromoCode() (string, error) {
+	return generateCode(promoCodeGroups)
+}
+
+// GenerateReferralCode returns a code already in its normalized form, so it
+// can be stored and shown as is.
+func GenerateReferralCode() (string, error) {
+	code, err := generateCode(referralCodeGroups)
+
+	return NormalizeCode(code), err
+}
+
+func generateCode(count int) (string, error) {
+	groups := make([]string, 0, count)
+	max := big.NewInt(int64(len(promoAlphabet)))
+
+	for i := 0; i < count; i++ {
+		group := make([]byte, promoGroupSize)
+
+		for j := range group {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+
+			group[j] = promoAlphabet[n.Int64()]
+		}
+
+		groups = append(groups, string(group))
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// NormalizeCode makes codes case-insensitive and ignores the dashes
+// they are printed with.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+// HashPromoCode keys the hash with a server secret. Admin-chosen codes can be
+// short, so a plain hash of a leaked table would be cheap to brute-force.
+func HashPromoCode(code, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(NormalizeCode(code)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PromoCodeHint is the tail of the code admins see in listings.
+func PromoCodeHint(code string) string {
+	normalized := NormalizeCode(code)
+
+	if len(normalized) <= promoHintSize {
+		return normalized
+	}
+
+	return normalized[len(normalized)-promoHintSize:]
+}
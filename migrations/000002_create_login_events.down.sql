@@ -0,0 +1 @@
+DROP TABLE IF EXISTS LOGIN_EVENTS;
\ No newline at end of file
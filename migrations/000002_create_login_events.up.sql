@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+LOGIN_EVENTS
+(
+    id bigserial primary key,
+    login varchar not null,
+    uid integer references users(uid),
+    ip varchar not null,
+    user_agent varchar,
+    outcome varchar not null,
+    created_at timestamp default current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS login_events_login_idx ON LOGIN_EVENTS (login, created_at);
+CREATE INDEX IF NOT EXISTS login_events_ip_idx ON LOGIN_EVENTS (ip, created_at);
+
+COMMIT;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS RECOVERY_CODES;
+ALTER TABLE USERS DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE USERS DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE USERS DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,18 @@
+BEGIN;
+
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS totp_secret varchar;
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS totp_enabled boolean not null default false;
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS totp_last_step bigint not null default 0;
+
+CREATE TABLE IF NOT EXISTS 
+RECOVERY_CODES
+(
+    id serial primary key,
+    uid integer references users(uid) on delete cascade,
+    code_hash varchar not null,
+    used_at timestamp
+);
+
+CREATE INDEX IF NOT EXISTS recovery_codes_uid_idx ON RECOVERY_CODES (uid);
+
+COMMIT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS SESSIONS;
\ No newline at end of file
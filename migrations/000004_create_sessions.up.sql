@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+SESSIONS
+(
+    id bigserial primary key,
+    uid integer references users(uid) on delete cascade,
+    ip varchar,
+    user_agent varchar,
+    created_at timestamp default current_timestamp,
+    last_seen_at timestamp default current_timestamp,
+    expires_at timestamp not null,
+    revoked_at timestamp
+);
+
+CREATE INDEX IF NOT EXISTS sessions_uid_idx ON SESSIONS (uid);
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS deleted_at timestamp;
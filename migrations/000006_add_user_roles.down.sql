@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS users_login_lower_idx;
+ALTER TABLE USERS DROP COLUMN IF EXISTS role;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS role varchar not null default 'user';
+
+CREATE INDEX IF NOT EXISTS users_login_lower_idx ON USERS (lower(login) text_pattern_ops);
+
+COMMIT;
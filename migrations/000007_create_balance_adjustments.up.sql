@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+BALANCE_ADJUSTMENTS
+(
+    id bigserial primary key,
+    uid integer not null references users(uid),
+    admin_uid integer references users(uid) on delete set null,
+    amount real not null,
+    reason varchar not null,
+    comment varchar not null,
+    created_at timestamp default current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS balance_adjustments_uid_idx ON BALANCE_ADJUSTMENTS (uid);
+
+COMMIT;
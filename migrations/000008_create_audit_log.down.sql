@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS AUDIT_LOG;
+DROP FUNCTION IF EXISTS audit_log_append_only();
@@ -0,0 +1,35 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+AUDIT_LOG
+(
+    id bigserial primary key,
+    actor varchar not null,
+    action varchar not null,
+    target varchar not null,
+    request_id varchar,
+    ip varchar,
+    before json,
+    after json,
+    created_at timestamptz not null,
+    prev_hash varchar not null,
+    hash varchar not null
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON AUDIT_LOG (actor);
+CREATE INDEX IF NOT EXISTS audit_log_target_idx ON AUDIT_LOG (target);
+CREATE INDEX IF NOT EXISTS audit_log_action_idx ON AUDIT_LOG (action);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'AUDIT_LOG is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE OR DELETE ON AUDIT_LOG
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON AUDIT_LOG
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+COMMIT;
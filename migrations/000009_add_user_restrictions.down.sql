@@ -0,0 +1,2 @@
+ALTER TABLE USERS DROP COLUMN IF EXISTS frozen_at;
+ALTER TABLE USERS DROP COLUMN IF EXISTS suspended_at;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS suspended_at timestamp;
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS frozen_at timestamp;
+
+COMMIT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS USER_MERGES;
+ALTER TABLE USERS DROP COLUMN IF EXISTS merged_into;
@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS merged_into integer references users(uid);
+
+CREATE TABLE IF NOT EXISTS 
+USER_MERGES
+(
+    id bigserial primary key,
+    source_uid integer not null references users(uid),
+    target_uid integer not null references users(uid),
+    admin_uid integer references users(uid) on delete set null,
+    moved_orders integer not null,
+    moved_withdrawals integer not null,
+    moved_balance real not null,
+    moved_withdrawn real not null,
+    created_at timestamp default current_timestamp
+);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+API_KEYS
+(
+    id bigserial primary key,
+    name varchar not null,
+    prefix varchar not null unique,
+    key_hash varchar not null,
+    scopes varchar[] not null,
+    rate_limit integer not null,
+    created_by integer references users(uid) on delete set null,
+    created_at timestamp default current_timestamp,
+    last_used_at timestamp,
+    revoked_at timestamp,
+    window_start timestamptz,
+    window_count integer not null default 0
+);
+
+COMMIT;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS ORDER_GOODS;
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE USERS DROP COLUMN IF EXISTS card_id;
+DROP TABLE IF EXISTS MERCHANTS;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+MERCHANTS
+(
+    id bigserial primary key,
+    name varchar not null,
+    prefix varchar not null unique,
+    key_hash varchar not null,
+    created_by integer references users(uid) on delete set null,
+    created_at timestamp default current_timestamp,
+    revoked_at timestamp
+);
+
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS card_id varchar unique;
+
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS merchant_id bigint references merchants(id);
+
+CREATE TABLE IF NOT EXISTS 
+ORDER_GOODS
+(
+    id bigserial primary key,
+    order_id bigint not null references orders(id) on delete cascade,
+    description varchar not null,
+    price real not null,
+    quantity integer not null default 1
+);
+
+CREATE INDEX IF NOT EXISTS order_goods_order_id_idx ON ORDER_GOODS (order_id);
+
+COMMIT;
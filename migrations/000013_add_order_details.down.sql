@@ -0,0 +1,2 @@
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS purchased_at;
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS total;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS total real;
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS purchased_at timestamptz;
+
+COMMIT;
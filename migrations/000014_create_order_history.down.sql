@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ORDER_HISTORY;
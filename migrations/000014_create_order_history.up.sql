@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+ORDER_HISTORY
+(
+    id bigserial primary key,
+    order_id bigint not null,
+    uid integer not null references users(uid),
+    event varchar not null,
+    status varchar not null,
+    created_at timestamp default current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS order_history_order_id_idx ON ORDER_HISTORY (order_id);
+
+COMMIT;
@@ -0,0 +1,2 @@
+ALTER TABLE ORDER_HISTORY DROP COLUMN IF EXISTS previous_uid;
+DROP TABLE IF EXISTS ORDER_DISPUTES;
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+ORDER_DISPUTES
+(
+    id bigserial primary key,
+    order_id bigint not null references orders(id) on delete cascade,
+    claimant_uid integer not null references users(uid),
+    holder_uid integer not null references users(uid),
+    evidence text not null,
+    status varchar not null default 'open',
+    resolution text,
+    resolved_by integer references users(uid) on delete set null,
+    created_at timestamp default current_timestamp,
+    resolved_at timestamp
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS order_disputes_open_idx ON ORDER_DISPUTES (order_id, claimant_uid) WHERE status = 'open';
+
+ALTER TABLE ORDER_HISTORY ADD COLUMN IF NOT EXISTS previous_uid integer references users(uid);
+
+COMMIT;
@@ -0,0 +1,3 @@
+ALTER TABLE ORDER_HISTORY DROP COLUMN IF EXISTS amount;
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS refunded;
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS net_accrual;
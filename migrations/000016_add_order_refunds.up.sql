@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS net_accrual real not null default 0;
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS refunded real not null default 0;
+
+UPDATE ORDERS SET net_accrual = COALESCE(accrual, 0);
+
+ALTER TABLE ORDER_HISTORY ADD COLUMN IF NOT EXISTS amount real;
+
+COMMIT;
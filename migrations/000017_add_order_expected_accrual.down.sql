@@ -0,0 +1,2 @@
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS expected_source;
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS expected_accrual;
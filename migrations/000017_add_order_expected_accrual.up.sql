@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS expected_accrual real;
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS expected_source varchar;
+
+COMMIT;
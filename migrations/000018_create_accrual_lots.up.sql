@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+ACCRUAL_LOTS
+(
+    id bigserial primary key,
+    uid integer not null references users(uid),
+    source varchar not null,
+    ref varchar,
+    amount real not null,
+    remaining real not null,
+    created_at timestamp default current_timestamp,
+    expired_at timestamp
+);
+
+CREATE INDEX IF NOT EXISTS accrual_lots_open_idx ON ACCRUAL_LOTS (uid, created_at) WHERE remaining > 0;
+
+INSERT INTO ACCRUAL_LOTS (uid, source, amount, remaining)
+SELECT uid, 'opening', current_balance, current_balance FROM USERS
+WHERE current_balance > 0;
+
+COMMIT;
@@ -0,0 +1,4 @@
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS bonus_accrual;
+ALTER TABLE USERS DROP COLUMN IF EXISTS tier_since;
+ALTER TABLE USERS DROP COLUMN IF EXISTS tier;
+DROP TABLE IF EXISTS TIERS;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+TIERS
+(
+    name varchar primary key,
+    min_accrual real not null unique,
+    multiplier real not null
+);
+
+INSERT INTO TIERS (name, min_accrual, multiplier) VALUES
+    ('standard', 0, 1),
+    ('silver', 1000, 1.1),
+    ('gold', 5000, 1.25),
+    ('platinum', 20000, 1.5)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS tier varchar not null default 'standard' references TIERS(name);
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS tier_since timestamp not null default current_timestamp;
+
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS bonus_accrual real not null default 0;
+
+COMMIT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS CAMPAIGN_REWARDS;
+DROP TABLE IF EXISTS CAMPAIGNS;
@@ -0,0 +1,34 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+CAMPAIGNS
+(
+    id bigserial primary key,
+    name varchar not null,
+    starts_at timestamptz not null,
+    ends_at timestamptz not null,
+    first_order boolean not null default false,
+    tier varchar references TIERS(name),
+    min_accrual real not null default 0,
+    reward_type varchar not null,
+    reward_value real not null,
+    user_cap real not null default 0,
+    created_by integer references users(uid) on delete set null,
+    created_at timestamp default current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS 
+CAMPAIGN_REWARDS
+(
+    id bigserial primary key,
+    campaign_id bigint not null references CAMPAIGNS(id),
+    uid integer not null references users(uid),
+    order_id bigint not null,
+    amount real not null,
+    created_at timestamp default current_timestamp,
+    unique (campaign_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS campaign_rewards_uid_idx ON CAMPAIGN_REWARDS (campaign_id, uid);
+
+COMMIT;
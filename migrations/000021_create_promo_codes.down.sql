@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS PROMO_REDEMPTIONS;
+DROP TABLE IF EXISTS PROMO_CODES;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+PROMO_CODES
+(
+    id bigserial primary key,
+    code_hash varchar not null unique,
+    hint varchar not null,
+    amount real not null,
+    max_redemptions integer not null default 0,
+    per_user_limit integer not null default 1,
+    redeemed integer not null default 0,
+    expires_at timestamptz not null,
+    revoked_at timestamptz,
+    created_by integer references users(uid) on delete set null,
+    created_at timestamp default current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS 
+PROMO_REDEMPTIONS
+(
+    id bigserial primary key,
+    code_id bigint not null references PROMO_CODES(id),
+    uid integer not null references users(uid),
+    amount real not null,
+    created_at timestamp default current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS promo_redemptions_code_uid_idx ON PROMO_REDEMPTIONS (code_id, uid);
+
+COMMIT;
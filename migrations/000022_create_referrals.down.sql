@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS REFERRALS;
+
+ALTER TABLE USERS DROP COLUMN IF EXISTS referral_code;
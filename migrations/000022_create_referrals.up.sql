@@ -0,0 +1,22 @@
+BEGIN;
+
+ALTER TABLE USERS ADD COLUMN IF NOT EXISTS referral_code varchar unique;
+
+CREATE TABLE IF NOT EXISTS 
+REFERRALS
+(
+    id bigserial primary key,
+    referrer_uid integer not null references users(uid),
+    referred_uid integer not null unique references users(uid),
+    status varchar not null default 'pending',
+    reason varchar,
+    order_id bigint,
+    referrer_bonus real not null default 0,
+    referee_bonus real not null default 0,
+    created_at timestamp default current_timestamp,
+    closed_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS referrals_referrer_idx ON REFERRALS (referrer_uid, created_at);
+
+COMMIT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS POINT_TRANSFERS;
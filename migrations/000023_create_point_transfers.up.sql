@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+POINT_TRANSFERS
+(
+    id bigserial primary key,
+    sender_uid integer not null references users(uid),
+    recipient_uid integer not null references users(uid),
+    amount real not null,
+    created_at timestamp default current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS point_transfers_sender_idx ON POINT_TRANSFERS (sender_uid, created_at);
+CREATE INDEX IF NOT EXISTS point_transfers_recipient_idx ON POINT_TRANSFERS (recipient_uid, created_at);
+
+COMMIT;
@@ -0,0 +1,7 @@
+ALTER TABLE ACCRUAL_LOTS DROP COLUMN IF EXISTS household_id;
+ALTER TABLE WITHDRAWALS DROP COLUMN IF EXISTS household_id;
+ALTER TABLE ORDERS DROP COLUMN IF EXISTS household_id;
+
+DROP TABLE IF EXISTS HOUSEHOLD_INVITES;
+DROP TABLE IF EXISTS HOUSEHOLD_MEMBERS;
+DROP TABLE IF EXISTS HOUSEHOLDS;
@@ -0,0 +1,40 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+HOUSEHOLDS
+(
+    id bigserial primary key,
+    name varchar not null,
+    owner_uid integer not null unique references users(uid),
+    balance real not null default 0,
+    created_at timestamp default current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS 
+HOUSEHOLD_MEMBERS
+(
+    uid integer primary key references users(uid),
+    household_id bigint not null references HOUSEHOLDS(id) on delete cascade,
+    spend_limit real,
+    joined_at timestamp default current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS household_members_household_idx ON HOUSEHOLD_MEMBERS (household_id);
+
+CREATE TABLE IF NOT EXISTS 
+HOUSEHOLD_INVITES
+(
+    id bigserial primary key,
+    household_id bigint not null references HOUSEHOLDS(id) on delete cascade,
+    uid integer not null references users(uid),
+    created_at timestamp default current_timestamp,
+    unique (household_id, uid)
+);
+
+ALTER TABLE ORDERS ADD COLUMN IF NOT EXISTS household_id bigint references HOUSEHOLDS(id) on delete set null;
+ALTER TABLE WITHDRAWALS ADD COLUMN IF NOT EXISTS household_id bigint references HOUSEHOLDS(id) on delete set null;
+ALTER TABLE ACCRUAL_LOTS ADD COLUMN IF NOT EXISTS household_id bigint references HOUSEHOLDS(id);
+
+CREATE INDEX IF NOT EXISTS accrual_lots_household_idx ON ACCRUAL_LOTS (household_id, created_at) WHERE remaining > 0 AND household_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS JOB_RUNS;
@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS 
+JOB_RUNS
+(
+    name varchar primary key,
+    finished_at timestamp not null
+);
+
+COMMIT;
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyStorager is an autogenerated mock type for the APIKeyStorager type
+type APIKeyStorager struct {
+	mock.Mock
+}
+
+// CreateAPIKey provides a mock function with given fields: _a0, _a1
+func (_m *APIKeyStorager) CreateAPIKey(_a0 context.Context, _a1 sharedtypes.APIKey) (sharedtypes.APIKey, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.APIKey) sharedtypes.APIKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.APIKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.APIKey) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAPIKey provides a mock function with given fields: _a0, _a1
+func (_m *APIKeyStorager) GetAPIKey(_a0 context.Context, _a1 string) (sharedtypes.APIKey, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.APIKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.APIKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HitRateLimit provides a mock function with given fields: _a0, _a1, _a2
+func (_m *APIKeyStorager) HitRateLimit(_a0 context.Context, _a1 string, _a2 uint) (int, int, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) int); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) int); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, uint) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAPIKeys provides a mock function with given fields: _a0
+func (_m *APIKeyStorager) ListAPIKeys(_a0 context.Context) ([]sharedtypes.APIKey, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []sharedtypes.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.APIKey); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAPIKey provides a mock function with given fields: _a0, _a1
+func (_m *APIKeyStorager) RevokeAPIKey(_a0 context.Context, _a1 string) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAPIKeyStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAPIKeyStorager creates a new instance of APIKeyStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAPIKeyStorager(t mockConstructorTestingTNewAPIKeyStorager) *APIKeyStorager {
+	mock := &APIKeyStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdjustmentStorager is an autogenerated mock type for the AdjustmentStorager type
+type AdjustmentStorager struct {
+	mock.Mock
+}
+
+// CreateAdjustment provides a mock function with given fields: _a0, _a1
+func (_m *AdjustmentStorager) CreateAdjustment(_a0 context.Context, _a1 sharedtypes.Adjustment) (sharedtypes.Adjustment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Adjustment
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Adjustment) sharedtypes.Adjustment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Adjustment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Adjustment) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAdjustments provides a mock function with given fields: _a0, _a1
+func (_m *AdjustmentStorager) ListAdjustments(_a0 context.Context, _a1 string) ([]sharedtypes.Adjustment, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.Adjustment
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Adjustment); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAdjustmentStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAdjustmentStorager creates a new instance of AdjustmentStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAdjustmentStorager(t mockConstructorTestingTNewAdjustmentStorager) *AdjustmentStorager {
+	mock := &AdjustmentStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
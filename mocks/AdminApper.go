@@ -0,0 +1,397 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminApper is an autogenerated mock type for the AdminApper type
+type AdminApper struct {
+	mock.Mock
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, adminUID, req
+func (_m *AdminApper) CreateAPIKey(ctx context.Context, adminUID string, req sharedtypes.APIKeyRequest) (sharedtypes.APIKeyCreated, error) {
+	ret := _m.Called(ctx, adminUID, req)
+
+	var r0 sharedtypes.APIKeyCreated
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.APIKeyRequest) sharedtypes.APIKeyCreated); ok {
+		r0 = rf(ctx, adminUID, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.APIKeyCreated)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.APIKeyRequest) error); ok {
+		r1 = rf(ctx, adminUID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAdjustment provides a mock function with given fields: ctx, adminUID, adj
+func (_m *AdminApper) CreateAdjustment(ctx context.Context, adminUID string, adj sharedtypes.Adjustment) (sharedtypes.Adjustment, error) {
+	ret := _m.Called(ctx, adminUID, adj)
+
+	var r0 sharedtypes.Adjustment
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.Adjustment) sharedtypes.Adjustment); ok {
+		r0 = rf(ctx, adminUID, adj)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Adjustment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.Adjustment) error); ok {
+		r1 = rf(ctx, adminUID, adj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCampaign provides a mock function with given fields: ctx, adminUID, c
+func (_m *AdminApper) CreateCampaign(ctx context.Context, adminUID string, c sharedtypes.Campaign) (sharedtypes.Campaign, error) {
+	ret := _m.Called(ctx, adminUID, c)
+
+	var r0 sharedtypes.Campaign
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.Campaign) sharedtypes.Campaign); ok {
+		r0 = rf(ctx, adminUID, c)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Campaign)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.Campaign) error); ok {
+		r1 = rf(ctx, adminUID, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateMerchant provides a mock function with given fields: ctx, adminUID, req
+func (_m *AdminApper) CreateMerchant(ctx context.Context, adminUID string, req sharedtypes.MerchantRequest) (sharedtypes.MerchantCreated, error) {
+	ret := _m.Called(ctx, adminUID, req)
+
+	var r0 sharedtypes.MerchantCreated
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.MerchantRequest) sharedtypes.MerchantCreated); ok {
+		r0 = rf(ctx, adminUID, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.MerchantCreated)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.MerchantRequest) error); ok {
+		r1 = rf(ctx, adminUID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndCampaign provides a mock function with given fields: ctx, id
+func (_m *AdminApper) EndCampaign(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOrder provides a mock function with given fields: ctx, number
+func (_m *AdminApper) GetOrder(ctx context.Context, number string) (sharedtypes.OrderOwner, error) {
+	ret := _m.Called(ctx, number)
+
+	var r0 sharedtypes.OrderOwner
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.OrderOwner); ok {
+		r0 = rf(ctx, number)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.OrderOwner)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, uid
+func (_m *AdminApper) GetUser(ctx context.Context, uid string) (sharedtypes.UserDetail, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.UserDetail
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.UserDetail); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.UserDetail)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAPIKeys provides a mock function with given fields: ctx
+func (_m *AdminApper) ListAPIKeys(ctx context.Context) ([]sharedtypes.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []sharedtypes.APIKey
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.APIKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAudit provides a mock function with given fields: ctx, filter
+func (_m *AdminApper) ListAudit(ctx context.Context, filter sharedtypes.AuditFilter) ([]sharedtypes.AuditEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []sharedtypes.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.AuditFilter) []sharedtypes.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCampaigns provides a mock function with given fields: ctx
+func (_m *AdminApper) ListCampaigns(ctx context.Context) ([]sharedtypes.Campaign, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []sharedtypes.Campaign
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.Campaign); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Campaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMerchants provides a mock function with given fields: ctx
+func (_m *AdminApper) ListMerchants(ctx context.Context) ([]sharedtypes.Merchant, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []sharedtypes.Merchant
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.Merchant); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Merchant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeUsers provides a mock function with given fields: ctx, adminUID, req
+func (_m *AdminApper) MergeUsers(ctx context.Context, adminUID string, req sharedtypes.MergeRequest) (sharedtypes.UserMerge, error) {
+	ret := _m.Called(ctx, adminUID, req)
+
+	var r0 sharedtypes.UserMerge
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.MergeRequest) sharedtypes.UserMerge); ok {
+		r0 = rf(ctx, adminUID, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.UserMerge)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.MergeRequest) error); ok {
+		r1 = rf(ctx, adminUID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, id
+func (_m *AdminApper) RevokeAPIKey(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeMerchant provides a mock function with given fields: ctx, id
+func (_m *AdminApper) RevokeMerchant(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: ctx, login
+func (_m *AdminApper) SearchUsers(ctx context.Context, login string) ([]sharedtypes.UserSummary, error) {
+	ret := _m.Called(ctx, login)
+
+	var r0 []sharedtypes.UserSummary
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.UserSummary); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.UserSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCardID provides a mock function with given fields: ctx, uid, cardID
+func (_m *AdminApper) SetCardID(ctx context.Context, uid string, cardID string) error {
+	ret := _m.Called(ctx, uid, cardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uid, cardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFrozen provides a mock function with given fields: ctx, uid, frozen
+func (_m *AdminApper) SetFrozen(ctx context.Context, uid string, frozen bool) error {
+	ret := _m.Called(ctx, uid, frozen)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, uid, frozen)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSuspended provides a mock function with given fields: ctx, uid, suspended
+func (_m *AdminApper) SetSuspended(ctx context.Context, uid string, suspended bool) error {
+	ret := _m.Called(ctx, uid, suspended)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, uid, suspended)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyAudit provides a mock function with given fields: ctx
+func (_m *AdminApper) VerifyAudit(ctx context.Context) (sharedtypes.AuditVerification, error) {
+	ret := _m.Called(ctx)
+
+	var r0 sharedtypes.AuditVerification
+	if rf, ok := ret.Get(0).(func(context.Context) sharedtypes.AuditVerification); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.AuditVerification)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAdminApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAdminApper creates a new instance of AdminApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAdminApper(t mockConstructorTestingTNewAdminApper) *AdminApper {
+	mock := &AdminApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
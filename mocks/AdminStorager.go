@@ -0,0 +1,179 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminStorager is an autogenerated mock type for the AdminStorager type
+type AdminStorager struct {
+	mock.Mock
+}
+
+// GetOrder provides a mock function with given fields: _a0, _a1
+func (_m *AdminStorager) GetOrder(_a0 context.Context, _a1 string) (sharedtypes.OrderOwner, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.OrderOwner
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.OrderOwner); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.OrderOwner)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserDetail provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AdminStorager) GetUserDetail(_a0 context.Context, _a1 string, _a2 int) (sharedtypes.UserDetail, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 sharedtypes.UserDetail
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) sharedtypes.UserDetail); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.UserDetail)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeUsers provides a mock function with given fields: _a0, _a1
+func (_m *AdminStorager) MergeUsers(_a0 context.Context, _a1 sharedtypes.UserMerge) (sharedtypes.UserMerge, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.UserMerge
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.UserMerge) sharedtypes.UserMerge); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.UserMerge)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.UserMerge) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUsers provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AdminStorager) SearchUsers(_a0 context.Context, _a1 string, _a2 int) ([]sharedtypes.UserSummary, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []sharedtypes.UserSummary
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []sharedtypes.UserSummary); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.UserSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCardID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AdminStorager) SetCardID(_a0 context.Context, _a1 string, _a2 string) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetFrozen provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AdminStorager) SetFrozen(_a0 context.Context, _a1 string, _a2 bool) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSuspended provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AdminStorager) SetSuspended(_a0 context.Context, _a1 string, _a2 bool) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAdminStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAdminStorager creates a new instance of AdminStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAdminStorager(t mockConstructorTestingTNewAdminStorager) *AdminStorager {
+	mock := &AdminStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,90 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditStorager is an autogenerated mock type for the AuditStorager type
+type AuditStorager struct {
+	mock.Mock
+}
+
+// AppendAudit provides a mock function with given fields: _a0, _a1
+func (_m *AuditStorager) AppendAudit(_a0 context.Context, _a1 sharedtypes.AuditEntry) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.AuditEntry) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAudit provides a mock function with given fields: _a0, _a1
+func (_m *AuditStorager) ListAudit(_a0 context.Context, _a1 sharedtypes.AuditFilter) ([]sharedtypes.AuditEntry, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.AuditFilter) []sharedtypes.AuditEntry); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.AuditFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuditChain provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AuditStorager) ListAuditChain(_a0 context.Context, _a1 int64, _a2 int) ([]sharedtypes.AuditEntry, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []sharedtypes.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []sharedtypes.AuditEntry); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAuditStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuditStorager creates a new instance of AuditStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuditStorager(t mockConstructorTestingTNewAuditStorager) *AuditStorager {
+	mock := &AuditStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,167 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CampaignStorager is an autogenerated mock type for the CampaignStorager type
+type CampaignStorager struct {
+	mock.Mock
+}
+
+// CreateCampaign provides a mock function with given fields: _a0, _a1
+func (_m *CampaignStorager) CreateCampaign(_a0 context.Context, _a1 sharedtypes.Campaign) (sharedtypes.Campaign, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Campaign
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Campaign) sharedtypes.Campaign); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Campaign)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Campaign) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndCampaign provides a mock function with given fields: _a0, _a1
+func (_m *CampaignStorager) EndCampaign(_a0 context.Context, _a1 string) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRewardSubject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *CampaignStorager) GetRewardSubject(_a0 context.Context, _a1 string, _a2 string) (sharedtypes.RewardSubject, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 sharedtypes.RewardSubject
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) sharedtypes.RewardSubject); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.RewardSubject)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantReward provides a mock function with given fields: ctx, reward, userCap
+func (_m *CampaignStorager) GrantReward(ctx context.Context, reward sharedtypes.CampaignReward, userCap float32) (sharedtypes.CampaignReward, bool, error) {
+	ret := _m.Called(ctx, reward, userCap)
+
+	var r0 sharedtypes.CampaignReward
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.CampaignReward, float32) sharedtypes.CampaignReward); ok {
+		r0 = rf(ctx, reward, userCap)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.CampaignReward)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.CampaignReward, float32) bool); ok {
+		r1 = rf(ctx, reward, userCap)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, sharedtypes.CampaignReward, float32) error); ok {
+		r2 = rf(ctx, reward, userCap)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListActiveCampaigns provides a mock function with given fields: _a0, _a1
+func (_m *CampaignStorager) ListActiveCampaigns(_a0 context.Context, _a1 string) ([]sharedtypes.Campaign, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.Campaign
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Campaign); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Campaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCampaigns provides a mock function with given fields: _a0
+func (_m *CampaignStorager) ListCampaigns(_a0 context.Context) ([]sharedtypes.Campaign, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []sharedtypes.Campaign
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.Campaign); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Campaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewCampaignStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCampaignStorager creates a new instance of CampaignStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCampaignStorager(t mockConstructorTestingTNewCampaignStorager) *CampaignStorager {
+	mock := &CampaignStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
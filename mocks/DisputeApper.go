@@ -0,0 +1,118 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DisputeApper is an autogenerated mock type for the DisputeApper type
+type DisputeApper struct {
+	mock.Mock
+}
+
+// ListDisputes provides a mock function with given fields: ctx, status
+func (_m *DisputeApper) ListDisputes(ctx context.Context, status string) ([]sharedtypes.Dispute, error) {
+	ret := _m.Called(ctx, status)
+
+	var r0 []sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Dispute); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Dispute)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserDisputes provides a mock function with given fields: ctx, uid
+func (_m *DisputeApper) ListUserDisputes(ctx context.Context, uid string) ([]sharedtypes.Dispute, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Dispute); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Dispute)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenDispute provides a mock function with given fields: ctx, uid, req
+func (_m *DisputeApper) OpenDispute(ctx context.Context, uid string, req sharedtypes.DisputeRequest) (sharedtypes.Dispute, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.DisputeRequest) sharedtypes.Dispute); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Dispute)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.DisputeRequest) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveDispute provides a mock function with given fields: ctx, adminUID, id, res
+func (_m *DisputeApper) ResolveDispute(ctx context.Context, adminUID string, id string, res sharedtypes.DisputeResolution) (sharedtypes.Dispute, error) {
+	ret := _m.Called(ctx, adminUID, id, res)
+
+	var r0 sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, sharedtypes.DisputeResolution) sharedtypes.Dispute); ok {
+		r0 = rf(ctx, adminUID, id, res)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Dispute)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, sharedtypes.DisputeResolution) error); ok {
+		r1 = rf(ctx, adminUID, id, res)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewDisputeApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewDisputeApper creates a new instance of DisputeApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewDisputeApper(t mockConstructorTestingTNewDisputeApper) *DisputeApper {
+	mock := &DisputeApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,139 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DisputeStorager is an autogenerated mock type for the DisputeStorager type
+type DisputeStorager struct {
+	mock.Mock
+}
+
+// CreateDispute provides a mock function with given fields: _a0, _a1
+func (_m *DisputeStorager) CreateDispute(_a0 context.Context, _a1 sharedtypes.Dispute) (sharedtypes.Dispute, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Dispute) sharedtypes.Dispute); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Dispute)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Dispute) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDispute provides a mock function with given fields: _a0, _a1
+func (_m *DisputeStorager) GetDispute(_a0 context.Context, _a1 string) (sharedtypes.Dispute, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Dispute); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Dispute)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDisputes provides a mock function with given fields: _a0, _a1, _a2
+func (_m *DisputeStorager) ListDisputes(_a0 context.Context, _a1 string, _a2 int) ([]sharedtypes.Dispute, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []sharedtypes.Dispute); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Dispute)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserDisputes provides a mock function with given fields: _a0, _a1
+func (_m *DisputeStorager) ListUserDisputes(_a0 context.Context, _a1 string) ([]sharedtypes.Dispute, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Dispute); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Dispute)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveDispute provides a mock function with given fields: _a0, _a1, _a2
+func (_m *DisputeStorager) ResolveDispute(_a0 context.Context, _a1 sharedtypes.Dispute, _a2 bool) (sharedtypes.Dispute, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 sharedtypes.Dispute
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Dispute, bool) sharedtypes.Dispute); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Dispute)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Dispute, bool) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewDisputeStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewDisputeStorager creates a new instance of DisputeStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewDisputeStorager(t mockConstructorTestingTNewDisputeStorager) *DisputeStorager {
+	mock := &DisputeStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
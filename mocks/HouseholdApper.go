@@ -0,0 +1,193 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HouseholdApper is an autogenerated mock type for the HouseholdApper type
+type HouseholdApper struct {
+	mock.Mock
+}
+
+// AcceptInvite provides a mock function with given fields: ctx, uid, id
+func (_m *HouseholdApper) AcceptInvite(ctx context.Context, uid string, id string) (sharedtypes.Household, error) {
+	ret := _m.Called(ctx, uid, id)
+
+	var r0 sharedtypes.Household
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) sharedtypes.Household); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Household)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateHousehold provides a mock function with given fields: ctx, uid, req
+func (_m *HouseholdApper) CreateHousehold(ctx context.Context, uid string, req sharedtypes.HouseholdRequest) (sharedtypes.Household, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 sharedtypes.Household
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.HouseholdRequest) sharedtypes.Household); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Household)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.HouseholdRequest) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeclineInvite provides a mock function with given fields: ctx, uid, id
+func (_m *HouseholdApper) DeclineInvite(ctx context.Context, uid string, id string) error {
+	ret := _m.Called(ctx, uid, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DissolveHousehold provides a mock function with given fields: ctx, uid
+func (_m *HouseholdApper) DissolveHousehold(ctx context.Context, uid string) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetHousehold provides a mock function with given fields: ctx, uid
+func (_m *HouseholdApper) GetHousehold(ctx context.Context, uid string) (sharedtypes.Household, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.Household
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Household); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Household)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InviteMember provides a mock function with given fields: ctx, uid, req
+func (_m *HouseholdApper) InviteMember(ctx context.Context, uid string, req sharedtypes.HouseholdInviteRequest) (sharedtypes.HouseholdInvite, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 sharedtypes.HouseholdInvite
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.HouseholdInviteRequest) sharedtypes.HouseholdInvite); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.HouseholdInvite)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.HouseholdInviteRequest) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvites provides a mock function with given fields: ctx, uid
+func (_m *HouseholdApper) ListInvites(ctx context.Context, uid string) ([]sharedtypes.HouseholdInvite, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []sharedtypes.HouseholdInvite
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.HouseholdInvite); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.HouseholdInvite)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, uid, memberUID
+func (_m *HouseholdApper) RemoveMember(ctx context.Context, uid string, memberUID string) error {
+	ret := _m.Called(ctx, uid, memberUID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uid, memberUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSpendLimit provides a mock function with given fields: ctx, uid, memberUID, limit
+func (_m *HouseholdApper) SetSpendLimit(ctx context.Context, uid string, memberUID string, limit *float32) error {
+	ret := _m.Called(ctx, uid, memberUID, limit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *float32) error); ok {
+		r0 = rf(ctx, uid, memberUID, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewHouseholdApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHouseholdApper creates a new instance of HouseholdApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHouseholdApper(t mockConstructorTestingTNewHouseholdApper) *HouseholdApper {
+	mock := &HouseholdApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,263 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HouseholdStorager is an autogenerated mock type for the HouseholdStorager type
+type HouseholdStorager struct {
+	mock.Mock
+}
+
+// AcceptInvite provides a mock function with given fields: ctx, uid, id
+func (_m *HouseholdStorager) AcceptInvite(ctx context.Context, uid string, id string) (string, error) {
+	ret := _m.Called(ctx, uid, id)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateHousehold provides a mock function with given fields: ctx, ownerUID, name
+func (_m *HouseholdStorager) CreateHousehold(ctx context.Context, ownerUID string, name string) (sharedtypes.Household, error) {
+	ret := _m.Called(ctx, ownerUID, name)
+
+	var r0 sharedtypes.Household
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) sharedtypes.Household); ok {
+		r0 = rf(ctx, ownerUID, name)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Household)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, ownerUID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvite provides a mock function with given fields: ctx, householdID, login
+func (_m *HouseholdStorager) CreateInvite(ctx context.Context, householdID string, login string) (sharedtypes.HouseholdInvite, error) {
+	ret := _m.Called(ctx, householdID, login)
+
+	var r0 sharedtypes.HouseholdInvite
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) sharedtypes.HouseholdInvite); ok {
+		r0 = rf(ctx, householdID, login)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.HouseholdInvite)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, householdID, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeclineInvite provides a mock function with given fields: ctx, uid, id
+func (_m *HouseholdStorager) DeclineInvite(ctx context.Context, uid string, id string) (bool, error) {
+	ret := _m.Called(ctx, uid, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DissolveHousehold provides a mock function with given fields: ctx, householdID, ownerUID
+func (_m *HouseholdStorager) DissolveHousehold(ctx context.Context, householdID string, ownerUID string) (float32, error) {
+	ret := _m.Called(ctx, householdID, ownerUID)
+
+	var r0 float32
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) float32); ok {
+		r0 = rf(ctx, householdID, ownerUID)
+	} else {
+		r0 = ret.Get(0).(float32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, householdID, ownerUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHousehold provides a mock function with given fields: _a0, _a1
+func (_m *HouseholdStorager) GetHousehold(_a0 context.Context, _a1 string) (sharedtypes.Household, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Household
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Household); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Household)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberBalance provides a mock function with given fields: _a0, _a1
+func (_m *HouseholdStorager) GetMemberBalance(_a0 context.Context, _a1 string) (sharedtypes.HouseholdBalance, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.HouseholdBalance
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.HouseholdBalance); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.HouseholdBalance)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvites provides a mock function with given fields: _a0, _a1
+func (_m *HouseholdStorager) ListInvites(_a0 context.Context, _a1 string) ([]sharedtypes.HouseholdInvite, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.HouseholdInvite
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.HouseholdInvite); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.HouseholdInvite)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, householdID, uid
+func (_m *HouseholdStorager) RemoveMember(ctx context.Context, householdID string, uid string) (bool, error) {
+	ret := _m.Called(ctx, householdID, uid)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, householdID, uid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, householdID, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSpendLimit provides a mock function with given fields: ctx, householdID, uid, limit
+func (_m *HouseholdStorager) SetSpendLimit(ctx context.Context, householdID string, uid string, limit *float32) (bool, error) {
+	ret := _m.Called(ctx, householdID, uid, limit)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *float32) bool); ok {
+		r0 = rf(ctx, householdID, uid, limit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *float32) error); ok {
+		r1 = rf(ctx, householdID, uid, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithdrawHousehold provides a mock function with given fields: ctx, uid, orderID, amount
+func (_m *HouseholdStorager) WithdrawHousehold(ctx context.Context, uid string, orderID string, amount float32) (sharedtypes.HouseholdBalance, error) {
+	ret := _m.Called(ctx, uid, orderID, amount)
+
+	var r0 sharedtypes.HouseholdBalance
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) sharedtypes.HouseholdBalance); ok {
+		r0 = rf(ctx, uid, orderID, amount)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.HouseholdBalance)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, float32) error); ok {
+		r1 = rf(ctx, uid, orderID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewHouseholdStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHouseholdStorager creates a new instance of HouseholdStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHouseholdStorager(t mockConstructorTestingTNewHouseholdStorager) *HouseholdStorager {
+	mock := &HouseholdStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
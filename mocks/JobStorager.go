@@ -0,0 +1,59 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobStorager is an autogenerated mock type for the JobStorager type
+type JobStorager struct {
+	mock.Mock
+}
+
+// ClaimJob provides a mock function with given fields: ctx, name, interval
+func (_m *JobStorager) ClaimJob(ctx context.Context, name string, interval uint) (func(bool) error, bool, error) {
+	ret := _m.Called(ctx, name, interval)
+
+	var r0 func(bool) error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) func(bool) error); ok {
+		r0 = rf(ctx, name, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func(bool) error)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) bool); ok {
+		r1 = rf(ctx, name, interval)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, uint) error); ok {
+		r2 = rf(ctx, name, interval)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewJobStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewJobStorager creates a new instance of JobStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewJobStorager(t mockConstructorTestingTNewJobStorager) *JobStorager {
+	mock := &JobStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
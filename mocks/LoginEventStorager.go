@@ -0,0 +1,72 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginEventStorager is an autogenerated mock type for the LoginEventStorager type
+type LoginEventStorager struct {
+	mock.Mock
+}
+
+// FinishLoginAttempt provides a mock function with given fields: ctx, event
+func (_m *LoginEventStorager) FinishLoginAttempt(ctx context.Context, event sharedtypes.LoginEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.LoginEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartLoginAttempt provides a mock function with given fields: ctx, event, window
+func (_m *LoginEventStorager) StartLoginAttempt(ctx context.Context, event sharedtypes.LoginEvent, window uint) (sharedtypes.LoginEvent, sharedtypes.LoginFailures, error) {
+	ret := _m.Called(ctx, event, window)
+
+	var r0 sharedtypes.LoginEvent
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.LoginEvent, uint) sharedtypes.LoginEvent); ok {
+		r0 = rf(ctx, event, window)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.LoginEvent)
+	}
+
+	var r1 sharedtypes.LoginFailures
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.LoginEvent, uint) sharedtypes.LoginFailures); ok {
+		r1 = rf(ctx, event, window)
+	} else {
+		r1 = ret.Get(1).(sharedtypes.LoginFailures)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, sharedtypes.LoginEvent, uint) error); ok {
+		r2 = rf(ctx, event, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewLoginEventStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoginEventStorager creates a new instance of LoginEventStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoginEventStorager(t mockConstructorTestingTNewLoginEventStorager) *LoginEventStorager {
+	mock := &LoginEventStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
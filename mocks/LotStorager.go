@@ -0,0 +1,155 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LotStorager is an autogenerated mock type for the LotStorager type
+type LotStorager struct {
+	mock.Mock
+}
+
+// ConsumeLots provides a mock function with given fields: _a0, _a1, _a2
+func (_m *LotStorager) ConsumeLots(_a0 context.Context, _a1 string, _a2 float32) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float32) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireHouseholdLots provides a mock function with given fields: ctx, householdID, lifetimeDays
+func (_m *LotStorager) ExpireHouseholdLots(ctx context.Context, householdID string, lifetimeDays int) (float32, error) {
+	ret := _m.Called(ctx, householdID, lifetimeDays)
+
+	var r0 float32
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) float32); ok {
+		r0 = rf(ctx, householdID, lifetimeDays)
+	} else {
+		r0 = ret.Get(0).(float32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, householdID, lifetimeDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireLots provides a mock function with given fields: _a0, _a1, _a2
+func (_m *LotStorager) ExpireLots(_a0 context.Context, _a1 sharedtypes.Adjustment, _a2 int) (sharedtypes.Adjustment, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 sharedtypes.Adjustment
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Adjustment, int) sharedtypes.Adjustment); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Adjustment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Adjustment, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExpiringPoints provides a mock function with given fields: _a0, _a1, _a2
+func (_m *LotStorager) ListExpiringPoints(_a0 context.Context, _a1 string, _a2 int) ([]sharedtypes.PointsExpiry, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []sharedtypes.PointsExpiry
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []sharedtypes.PointsExpiry); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.PointsExpiry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHouseholdsWithExpiredLots provides a mock function with given fields: ctx, lifetimeDays, afterID, limit
+func (_m *LotStorager) ListHouseholdsWithExpiredLots(ctx context.Context, lifetimeDays int, afterID string, limit int) ([]string, error) {
+	ret := _m.Called(ctx, lifetimeDays, afterID, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) []string); ok {
+		r0 = rf(ctx, lifetimeDays, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, lifetimeDays, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsersWithExpiredLots provides a mock function with given fields: ctx, lifetimeDays, afterUID, limit
+func (_m *LotStorager) ListUsersWithExpiredLots(ctx context.Context, lifetimeDays int, afterUID string, limit int) ([]string, error) {
+	ret := _m.Called(ctx, lifetimeDays, afterUID, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) []string); ok {
+		r0 = rf(ctx, lifetimeDays, afterUID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, lifetimeDays, afterUID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewLotStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLotStorager creates a new instance of LotStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLotStorager(t mockConstructorTestingTNewLotStorager) *LotStorager {
+	mock := &LotStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
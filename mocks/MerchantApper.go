@@ -0,0 +1,65 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MerchantApper is an autogenerated mock type for the MerchantApper type
+type MerchantApper struct {
+	mock.Mock
+}
+
+// CreateOrder provides a mock function with given fields: ctx, order
+func (_m *MerchantApper) CreateOrder(ctx context.Context, order sharedtypes.MerchantOrder) error {
+	ret := _m.Called(ctx, order)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.MerchantOrder) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefundOrder provides a mock function with given fields: ctx, merchantID, number, req
+func (_m *MerchantApper) RefundOrder(ctx context.Context, merchantID string, number string, req sharedtypes.RefundRequest) (sharedtypes.Refund, error) {
+	ret := _m.Called(ctx, merchantID, number, req)
+
+	var r0 sharedtypes.Refund
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, sharedtypes.RefundRequest) sharedtypes.Refund); ok {
+		r0 = rf(ctx, merchantID, number, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Refund)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, sharedtypes.RefundRequest) error); ok {
+		r1 = rf(ctx, merchantID, number, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMerchantApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMerchantApper creates a new instance of MerchantApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMerchantApper(t mockConstructorTestingTNewMerchantApper) *MerchantApper {
+	mock := &MerchantApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,186 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MerchantStorager is an autogenerated mock type for the MerchantStorager type
+type MerchantStorager struct {
+	mock.Mock
+}
+
+// CreateMerchant provides a mock function with given fields: _a0, _a1
+func (_m *MerchantStorager) CreateMerchant(_a0 context.Context, _a1 sharedtypes.Merchant) (sharedtypes.Merchant, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Merchant
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Merchant) sharedtypes.Merchant); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Merchant)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Merchant) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindCustomer provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MerchantStorager) FindCustomer(_a0 context.Context, _a1 string, _a2 string) (string, bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMerchant provides a mock function with given fields: _a0, _a1
+func (_m *MerchantStorager) GetMerchant(_a0 context.Context, _a1 string) (sharedtypes.Merchant, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Merchant
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Merchant); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Merchant)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefundableOrder provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MerchantStorager) GetRefundableOrder(_a0 context.Context, _a1 string, _a2 string) (sharedtypes.RefundableOrder, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 sharedtypes.RefundableOrder
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) sharedtypes.RefundableOrder); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.RefundableOrder)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMerchants provides a mock function with given fields: _a0
+func (_m *MerchantStorager) ListMerchants(_a0 context.Context) ([]sharedtypes.Merchant, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []sharedtypes.Merchant
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.Merchant); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Merchant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundOrder provides a mock function with given fields: ctx, refund, prev, policy
+func (_m *MerchantStorager) RefundOrder(ctx context.Context, refund sharedtypes.Refund, prev sharedtypes.RefundableOrder, policy string) (sharedtypes.Refund, error) {
+	ret := _m.Called(ctx, refund, prev, policy)
+
+	var r0 sharedtypes.Refund
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Refund, sharedtypes.RefundableOrder, string) sharedtypes.Refund); ok {
+		r0 = rf(ctx, refund, prev, policy)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Refund)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Refund, sharedtypes.RefundableOrder, string) error); ok {
+		r1 = rf(ctx, refund, prev, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeMerchant provides a mock function with given fields: _a0, _a1
+func (_m *MerchantStorager) RevokeMerchant(_a0 context.Context, _a1 string) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMerchantStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMerchantStorager creates a new instance of MerchantStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMerchantStorager(t mockConstructorTestingTNewMerchantStorager) *MerchantStorager {
+	mock := &MerchantStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,34 @@ type OrderApper struct {
 	mock.Mock
 }
 
+// CancelOrder provides a mock function with given fields: ctx, uid, orderID
+func (_m *OrderApper) CancelOrder(ctx context.Context, uid string, orderID string) error {
+	ret := _m.Called(ctx, uid, orderID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uid, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMerchantOrder provides a mock function with given fields: ctx, order
+func (_m *OrderApper) CreateMerchantOrder(ctx context.Context, order sharedtypes.MerchantOrder) error {
+	ret := _m.Called(ctx, order)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.MerchantOrder) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateOrder provides a mock function with given fields: ctx, orderID, uid
 func (_m *OrderApper) CreateOrder(ctx context.Context, orderID string, uid string) error {
 	ret := _m.Called(ctx, orderID, uid)
@@ -28,6 +56,22 @@ func (_m *OrderApper) CreateOrder(ctx context.Context, orderID string, uid strin
 	return r0
 }
 
+// CreateOrders provides a mock function with given fields: ctx, uid, numbers
+func (_m *OrderApper) CreateOrders(ctx context.Context, uid string, numbers []string) []sharedtypes.OrderResult {
+	ret := _m.Called(ctx, uid, numbers)
+
+	var r0 []sharedtypes.OrderResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []sharedtypes.OrderResult); ok {
+		r0 = rf(ctx, uid, numbers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.OrderResult)
+		}
+	}
+
+	return r0
+}
+
 // GetUnproccessedOrders provides a mock function with given fields: ctx
 func (_m *OrderApper) GetUnproccessedOrders(ctx context.Context) ([]sharedtypes.Order, error) {
 	ret := _m.Called(ctx)
@@ -74,6 +118,20 @@ func (_m *OrderApper) ListOrders(ctx context.Context, uid string) ([]sharedtypes
 	return r0, r1
 }
 
+// SubmitOrder provides a mock function with given fields: ctx, uid, order
+func (_m *OrderApper) SubmitOrder(ctx context.Context, uid string, order sharedtypes.OrderSubmission) error {
+	ret := _m.Called(ctx, uid, order)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.OrderSubmission) error); ok {
+		r0 = rf(ctx, uid, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateOrder provides a mock function with given fields: ctx, uid, orderID, amount, user
 func (_m *OrderApper) UpdateOrder(ctx context.Context, uid string, orderID string, amount float32, user sharedtypes.UserApper) error {
 	ret := _m.Called(ctx, uid, orderID, amount, user)
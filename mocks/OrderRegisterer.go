@@ -0,0 +1,44 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrderRegisterer is an autogenerated mock type for the OrderRegisterer type
+type OrderRegisterer struct {
+	mock.Mock
+}
+
+// RegisterOrder provides a mock function with given fields: ctx, orderID, goods
+func (_m *OrderRegisterer) RegisterOrder(ctx context.Context, orderID string, goods []sharedtypes.Good) error {
+	ret := _m.Called(ctx, orderID, goods)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []sharedtypes.Good) error); ok {
+		r0 = rf(ctx, orderID, goods)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewOrderRegisterer interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOrderRegisterer creates a new instance of OrderRegisterer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOrderRegisterer(t mockConstructorTestingTNewOrderRegisterer) *OrderRegisterer {
+	mock := &OrderRegisterer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,34 @@ type OrderStorager struct {
 	mock.Mock
 }
 
+// CancelOrder provides a mock function with given fields: _a0, _a1, _a2
+func (_m *OrderStorager) CancelOrder(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateDetailedOrder provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *OrderStorager) CreateDetailedOrder(_a0 context.Context, _a1 string, _a2 string, _a3 sharedtypes.OrderSubmission) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, sharedtypes.OrderSubmission) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateOrder provides a mock function with given fields: _a0, _a1, _a2
 func (_m *OrderStorager) CreateOrder(_a0 context.Context, _a1 string, _a2 string) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PartnerApper is an autogenerated mock type for the PartnerApper type
+type PartnerApper struct {
+	mock.Mock
+}
+
+// CreateOrder provides a mock function with given fields: ctx, uid, orderID
+func (_m *PartnerApper) CreateOrder(ctx context.Context, uid string, orderID string) error {
+	ret := _m.Called(ctx, uid, orderID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uid, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBalance provides a mock function with given fields: ctx, uid
+func (_m *PartnerApper) GetBalance(ctx context.Context, uid string) (sharedtypes.Balance, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.Balance
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Balance); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Balance)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPartnerApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPartnerApper creates a new instance of PartnerApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPartnerApper(t mockConstructorTestingTNewPartnerApper) *PartnerApper {
+	mock := &PartnerApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
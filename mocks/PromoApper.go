@@ -0,0 +1,109 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PromoApper is an autogenerated mock type for the PromoApper type
+type PromoApper struct {
+	mock.Mock
+}
+
+// CreatePromoCode provides a mock function with given fields: ctx, adminUID, p
+func (_m *PromoApper) CreatePromoCode(ctx context.Context, adminUID string, p sharedtypes.PromoCode) (sharedtypes.PromoCode, error) {
+	ret := _m.Called(ctx, adminUID, p)
+
+	var r0 sharedtypes.PromoCode
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.PromoCode) sharedtypes.PromoCode); ok {
+		r0 = rf(ctx, adminUID, p)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.PromoCode)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.PromoCode) error); ok {
+		r1 = rf(ctx, adminUID, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPromoCodes provides a mock function with given fields: ctx
+func (_m *PromoApper) ListPromoCodes(ctx context.Context) ([]sharedtypes.PromoCode, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []sharedtypes.PromoCode
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.PromoCode); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.PromoCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeemPromoCode provides a mock function with given fields: ctx, uid, code
+func (_m *PromoApper) RedeemPromoCode(ctx context.Context, uid string, code string) (sharedtypes.PromoRedemption, error) {
+	ret := _m.Called(ctx, uid, code)
+
+	var r0 sharedtypes.PromoRedemption
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) sharedtypes.PromoRedemption); ok {
+		r0 = rf(ctx, uid, code)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.PromoRedemption)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokePromoCode provides a mock function with given fields: ctx, id
+func (_m *PromoApper) RevokePromoCode(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPromoApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPromoApper creates a new instance of PromoApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPromoApper(t mockConstructorTestingTNewPromoApper) *PromoApper {
+	mock := &PromoApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
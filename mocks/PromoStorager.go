@@ -0,0 +1,116 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PromoStorager is an autogenerated mock type for the PromoStorager type
+type PromoStorager struct {
+	mock.Mock
+}
+
+// CreatePromoCode provides a mock function with given fields: _a0, _a1
+func (_m *PromoStorager) CreatePromoCode(_a0 context.Context, _a1 sharedtypes.PromoCode) (sharedtypes.PromoCode, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.PromoCode
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.PromoCode) sharedtypes.PromoCode); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.PromoCode)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.PromoCode) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPromoCodes provides a mock function with given fields: _a0
+func (_m *PromoStorager) ListPromoCodes(_a0 context.Context) ([]sharedtypes.PromoCode, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []sharedtypes.PromoCode
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.PromoCode); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.PromoCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeemPromoCode provides a mock function with given fields: ctx, uid, hash
+func (_m *PromoStorager) RedeemPromoCode(ctx context.Context, uid string, hash string) (sharedtypes.PromoRedemption, error) {
+	ret := _m.Called(ctx, uid, hash)
+
+	var r0 sharedtypes.PromoRedemption
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) sharedtypes.PromoRedemption); ok {
+		r0 = rf(ctx, uid, hash)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.PromoRedemption)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokePromoCode provides a mock function with given fields: _a0, _a1
+func (_m *PromoStorager) RevokePromoCode(_a0 context.Context, _a1 string) (bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPromoStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPromoStorager creates a new instance of PromoStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPromoStorager(t mockConstructorTestingTNewPromoStorager) *PromoStorager {
+	mock := &PromoStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,193 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReferralStorager is an autogenerated mock type for the ReferralStorager type
+type ReferralStorager struct {
+	mock.Mock
+}
+
+// CreateReferredUser provides a mock function with given fields: ctx, creds, referrerUID, dailyLimit
+func (_m *ReferralStorager) CreateReferredUser(ctx context.Context, creds sharedtypes.Credentials, referrerUID string, dailyLimit int) (string, error) {
+	ret := _m.Called(ctx, creds, referrerUID, dailyLimit)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Credentials, string, int) string); ok {
+		r0 = rf(ctx, creds, referrerUID, dailyLimit)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Credentials, string, int) error); ok {
+		r1 = rf(ctx, creds, referrerUID, dailyLimit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingReferral provides a mock function with given fields: _a0, _a1
+func (_m *ReferralStorager) GetPendingReferral(_a0 context.Context, _a1 string) (sharedtypes.Referral, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Referral
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Referral); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Referral)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReferralCode provides a mock function with given fields: _a0, _a1
+func (_m *ReferralStorager) GetReferralCode(_a0 context.Context, _a1 string) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReferrer provides a mock function with given fields: _a0, _a1
+func (_m *ReferralStorager) GetReferrer(_a0 context.Context, _a1 string) (sharedtypes.Referrer, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.Referrer
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Referrer); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Referrer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReferrals provides a mock function with given fields: _a0, _a1
+func (_m *ReferralStorager) ListReferrals(_a0 context.Context, _a1 string) ([]sharedtypes.Referral, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.Referral
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Referral); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Referral)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectReferral provides a mock function with given fields: ctx, id, reason
+func (_m *ReferralStorager) RejectReferral(ctx context.Context, id string, reason string) error {
+	ret := _m.Called(ctx, id, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RewardReferral provides a mock function with given fields: ctx, r, maxRewards
+func (_m *ReferralStorager) RewardReferral(ctx context.Context, r sharedtypes.Referral, maxRewards int) (sharedtypes.Referral, error) {
+	ret := _m.Called(ctx, r, maxRewards)
+
+	var r0 sharedtypes.Referral
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Referral, int) sharedtypes.Referral); ok {
+		r0 = rf(ctx, r, maxRewards)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Referral)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Referral, int) error); ok {
+		r1 = rf(ctx, r, maxRewards)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetReferralCode provides a mock function with given fields: ctx, uid, code
+func (_m *ReferralStorager) SetReferralCode(ctx context.Context, uid string, code string) (string, error) {
+	ret := _m.Called(ctx, uid, code)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, uid, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewReferralStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewReferralStorager creates a new instance of ReferralStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewReferralStorager(t mockConstructorTestingTNewReferralStorager) *ReferralStorager {
+	mock := &ReferralStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
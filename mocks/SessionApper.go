@@ -0,0 +1,67 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionApper is an autogenerated mock type for the SessionApper type
+type SessionApper struct {
+	mock.Mock
+}
+
+// ListSessions provides a mock function with given fields: ctx, uid, currentSID
+func (_m *SessionApper) ListSessions(ctx context.Context, uid string, currentSID string) ([]sharedtypes.Session, error) {
+	ret := _m.Called(ctx, uid, currentSID)
+
+	var r0 []sharedtypes.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []sharedtypes.Session); ok {
+		r0 = rf(ctx, uid, currentSID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, currentSID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, uid, sid
+func (_m *SessionApper) RevokeSession(ctx context.Context, uid string, sid string) error {
+	ret := _m.Called(ctx, uid, sid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uid, sid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSessionApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionApper creates a new instance of SessionApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionApper(t mockConstructorTestingTNewSessionApper) *SessionApper {
+	mock := &SessionApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
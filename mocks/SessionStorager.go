@@ -0,0 +1,137 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionStorager is an autogenerated mock type for the SessionStorager type
+type SessionStorager struct {
+	mock.Mock
+}
+
+// CreateSession provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *SessionStorager) CreateSession(_a0 context.Context, _a1 string, _a2 sharedtypes.Client, _a3 int64) (string, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.Client, int64) string); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.Client, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSessions provides a mock function with given fields: _a0, _a1
+func (_m *SessionStorager) ListSessions(_a0 context.Context, _a1 string) ([]sharedtypes.Session, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Session); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: _a0, _a1, _a2
+func (_m *SessionStorager) RevokeSession(_a0 context.Context, _a1 string, _a2 string) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeUserSessions provides a mock function with given fields: _a0, _a1
+func (_m *SessionStorager) RevokeUserSessions(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TouchSession provides a mock function with given fields: ctx, sid, uid
+func (_m *SessionStorager) TouchSession(ctx context.Context, sid string, uid string) (string, bool, error) {
+	ret := _m.Called(ctx, sid, uid)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, sid, uid)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, sid, uid)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, sid, uid)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewSessionStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionStorager creates a new instance of SessionStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionStorager(t mockConstructorTestingTNewSessionStorager) *SessionStorager {
+	mock := &SessionStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
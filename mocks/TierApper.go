@@ -0,0 +1,100 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TierApper is an autogenerated mock type for the TierApper type
+type TierApper struct {
+	mock.Mock
+}
+
+// GetTier provides a mock function with given fields: ctx, uid
+func (_m *TierApper) GetTier(ctx context.Context, uid string) (sharedtypes.TierStatus, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.TierStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.TierStatus); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.TierStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecalculateTiers provides a mock function with given fields: ctx
+func (_m *TierApper) RecalculateTiers(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecalculateTiersIfDue provides a mock function with given fields: ctx
+func (_m *TierApper) RecalculateTiersIfDue(ctx context.Context) (int, bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context) bool); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewTierApper interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTierApper creates a new instance of TierApper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTierApper(t mockConstructorTestingTNewTierApper) *TierApper {
+	mock := &TierApper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
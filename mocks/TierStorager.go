@@ -0,0 +1,132 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TierStorager is an autogenerated mock type for the TierStorager type
+type TierStorager struct {
+	mock.Mock
+}
+
+// GetMultiplier provides a mock function with given fields: _a0, _a1
+func (_m *TierStorager) GetMultiplier(_a0 context.Context, _a1 string) (float32, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 float32
+	if rf, ok := ret.Get(0).(func(context.Context, string) float32); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(float32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTierMember provides a mock function with given fields: _a0, _a1
+func (_m *TierStorager) GetTierMember(_a0 context.Context, _a1 string) (sharedtypes.TierMember, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.TierMember
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.TierMember); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.TierMember)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTierMembers provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TierStorager) ListTierMembers(_a0 context.Context, _a1 string, _a2 int) ([]sharedtypes.TierMember, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []sharedtypes.TierMember
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []sharedtypes.TierMember); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.TierMember)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTiers provides a mock function with given fields: _a0
+func (_m *TierStorager) ListTiers(_a0 context.Context) ([]sharedtypes.Tier, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []sharedtypes.Tier
+	if rf, ok := ret.Get(0).(func(context.Context) []sharedtypes.Tier); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Tier)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTier provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TierStorager) SetTier(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTierStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTierStorager creates a new instance of TierStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTierStorager(t mockConstructorTestingTNewTierStorager) *TierStorager {
+	mock := &TierStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sharedtypes "github.com/T-V-N/gopherstore/internal/shared_types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransferStorager is an autogenerated mock type for the TransferStorager type
+type TransferStorager struct {
+	mock.Mock
+}
+
+// GetRecipient provides a mock function with given fields: ctx, login
+func (_m *TransferStorager) GetRecipient(ctx context.Context, login string) (string, error) {
+	ret := _m.Called(ctx, login)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, login)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTransfers provides a mock function with given fields: _a0, _a1
+func (_m *TransferStorager) ListTransfers(_a0 context.Context, _a1 string) ([]sharedtypes.Transfer, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Transfer); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransferPoints provides a mock function with given fields: ctx, t, limits
+func (_m *TransferStorager) TransferPoints(ctx context.Context, t sharedtypes.Transfer, limits sharedtypes.TransferLimits) (sharedtypes.Transfer, error) {
+	ret := _m.Called(ctx, t, limits)
+
+	var r0 sharedtypes.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Transfer, sharedtypes.TransferLimits) sharedtypes.Transfer); ok {
+		r0 = rf(ctx, t, limits)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Transfer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, sharedtypes.Transfer, sharedtypes.TransferLimits) error); ok {
+		r1 = rf(ctx, t, limits)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTransferStorager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransferStorager creates a new instance of TransferStorager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransferStorager(t mockConstructorTestingTNewTransferStorager) *TransferStorager {
+	mock := &TransferStorager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
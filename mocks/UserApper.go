@@ -14,6 +14,120 @@ type UserApper struct {
 	mock.Mock
 }
 
+// ConfirmTOTP provides a mock function with given fields: ctx, uid, code
+func (_m *UserApper) ConfirmTOTP(ctx context.Context, uid string, code string) ([]string, error) {
+	ret := _m.Called(ctx, uid, code)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, uid, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, uid, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, uid, req
+func (_m *UserApper) DeleteAccount(ctx context.Context, uid string, req sharedtypes.AccountDeletion) error {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.AccountDeletion) error); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, uid, code
+func (_m *UserApper) DisableTOTP(ctx context.Context, uid string, code string) error {
+	ret := _m.Called(ctx, uid, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, uid, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, uid
+func (_m *UserApper) EnrollTOTP(ctx context.Context, uid string) (sharedtypes.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.TOTPEnrollment
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.TOTPEnrollment); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.TOTPEnrollment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpirePoints provides a mock function with given fields: ctx
+func (_m *UserApper) ExpirePoints(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportData provides a mock function with given fields: ctx, uid
+func (_m *UserApper) ExportData(ctx context.Context, uid string) (sharedtypes.UserData, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.UserData
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.UserData); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.UserData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBalance provides a mock function with given fields: ctx, uid
 func (_m *UserApper) GetBalance(ctx context.Context, uid string) (sharedtypes.Balance, error) {
 	ret := _m.Called(ctx, uid)
@@ -35,15 +149,126 @@ func (_m *UserApper) GetBalance(ctx context.Context, uid string) (sharedtypes.Ba
 	return r0, r1
 }
 
+// ListAdjustments provides a mock function with given fields: ctx, uid
+func (_m *UserApper) ListAdjustments(ctx context.Context, uid string) ([]sharedtypes.Adjustment, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []sharedtypes.Adjustment
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Adjustment); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExpiringPoints provides a mock function with given fields: ctx, uid
+func (_m *UserApper) ListExpiringPoints(ctx context.Context, uid string) (sharedtypes.ExpiringPoints, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.ExpiringPoints
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.ExpiringPoints); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.ExpiringPoints)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPendingAccruals provides a mock function with given fields: ctx, uid
+func (_m *UserApper) ListPendingAccruals(ctx context.Context, uid string) ([]sharedtypes.PendingAccrual, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []sharedtypes.PendingAccrual
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.PendingAccrual); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.PendingAccrual)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReferrals provides a mock function with given fields: ctx, uid
+func (_m *UserApper) ListReferrals(ctx context.Context, uid string) (sharedtypes.ReferralSummary, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 sharedtypes.ReferralSummary
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.ReferralSummary); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.ReferralSummary)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTransfers provides a mock function with given fields: ctx, uid
+func (_m *UserApper) ListTransfers(ctx context.Context, uid string) ([]sharedtypes.Transfer, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []sharedtypes.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.Transfer); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, creds
-func (_m *UserApper) Login(ctx context.Context, creds sharedtypes.Credentials) (string, error) {
+func (_m *UserApper) Login(ctx context.Context, creds sharedtypes.Credentials) (sharedtypes.LoginResult, error) {
 	ret := _m.Called(ctx, creds)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Credentials) string); ok {
+	var r0 sharedtypes.LoginResult
+	if rf, ok := ret.Get(0).(func(context.Context, sharedtypes.Credentials) sharedtypes.LoginResult); ok {
 		r0 = rf(ctx, creds)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(sharedtypes.LoginResult)
 	}
 
 	var r1 error
@@ -56,6 +281,27 @@ func (_m *UserApper) Login(ctx context.Context, creds sharedtypes.Credentials) (
 	return r0, r1
 }
 
+// LoginTwoFactor provides a mock function with given fields: ctx, challenge, code
+func (_m *UserApper) LoginTwoFactor(ctx context.Context, challenge string, code string) (string, error) {
+	ret := _m.Called(ctx, challenge, code)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, challenge, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, challenge, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: ctx, creds
 func (_m *UserApper) Register(ctx context.Context, creds sharedtypes.Credentials) (string, error) {
 	ret := _m.Called(ctx, creds)
@@ -77,13 +323,34 @@ func (_m *UserApper) Register(ctx context.Context, creds sharedtypes.Credentials
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, uid, orderID, amount
-func (_m *UserApper) UpdateUser(ctx context.Context, uid string, orderID string, amount float32) error {
-	ret := _m.Called(ctx, uid, orderID, amount)
+// TransferBalance provides a mock function with given fields: ctx, uid, req
+func (_m *UserApper) TransferBalance(ctx context.Context, uid string, req sharedtypes.TransferRequest) (sharedtypes.Transfer, error) {
+	ret := _m.Called(ctx, uid, req)
+
+	var r0 sharedtypes.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, string, sharedtypes.TransferRequest) sharedtypes.Transfer); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Transfer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, sharedtypes.TransferRequest) error); ok {
+		r1 = rf(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: ctx, uid, orderID, accrual, bonus
+func (_m *UserApper) UpdateUser(ctx context.Context, uid string, orderID string, accrual float32, bonus float32) error {
+	ret := _m.Called(ctx, uid, orderID, accrual, bonus)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(ctx, uid, orderID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32, float32) error); ok {
+		r0 = rf(ctx, uid, orderID, accrual, bonus)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,13 +358,13 @@ func (_m *UserApper) UpdateUser(ctx context.Context, uid string, orderID string,
 	return r0
 }
 
-// WithdrawBalance provides a mock function with given fields: ctx, uid, orderID, amount
-func (_m *UserApper) WithdrawBalance(ctx context.Context, uid string, orderID string, amount float32) error {
-	ret := _m.Called(ctx, uid, orderID, amount)
+// WithdrawBalance provides a mock function with given fields: ctx, uid, orderID, amount, source
+func (_m *UserApper) WithdrawBalance(ctx context.Context, uid string, orderID string, amount float32, source string) error {
+	ret := _m.Called(ctx, uid, orderID, amount, source)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(ctx, uid, orderID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32, string) error); ok {
+		r0 = rf(ctx, uid, orderID, amount, source)
 	} else {
 		r0 = ret.Error(0)
 	}
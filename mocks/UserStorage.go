@@ -9,7 +9,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
-// UserStorage is an autogenerated mock type for the UserStorage type
+// UserStorage is an autogenerated mock type for the UserStorager type
 type UserStorage struct {
 	mock.Mock
 }
@@ -35,15 +35,15 @@ func (_m *UserStorage) CreateUser(_a0 context.Context, _a1 sharedtypes.Credentia
 	return r0, r1
 }
 
-// GetBalance provides a mock function with given fields: _a0, _a1
-func (_m *UserStorage) GetBalance(_a0 context.Context, _a1 string) (sharedtypes.Balance, error) {
+// DeleteUser provides a mock function with given fields: _a0, _a1
+func (_m *UserStorage) DeleteUser(_a0 context.Context, _a1 string) (string, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 sharedtypes.Balance
-	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.Balance); ok {
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Get(0).(sharedtypes.Balance)
+		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
@@ -56,8 +56,57 @@ func (_m *UserStorage) GetBalance(_a0 context.Context, _a1 string) (sharedtypes.
 	return r0, r1
 }
 
-// GetBalanceAndLock provides a mock function with given fields: _a0, _a1
-func (_m *UserStorage) GetBalanceAndLock(_a0 context.Context, _a1 string) (sharedtypes.Balance, error) {
+// DisableTOTP provides a mock function with given fields: _a0, _a1
+func (_m *UserStorage) DisableTOTP(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTOTP provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *UserStorage) EnableTOTP(_a0 context.Context, _a1 string, _a2 int64, _a3 []string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportUserData provides a mock function with given fields: _a0, _a1
+func (_m *UserStorage) ExportUserData(_a0 context.Context, _a1 string) (sharedtypes.UserData, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.UserData
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.UserData); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.UserData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: _a0, _a1
+func (_m *UserStorage) GetBalance(_a0 context.Context, _a1 string) (sharedtypes.Balance, error) {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 sharedtypes.Balance
@@ -98,13 +147,71 @@ func (_m *UserStorage) GetUser(_a0 context.Context, _a1 sharedtypes.Credentials)
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *UserStorage) UpdateUser(_a0 context.Context, _a1 string, _a2 string, _a3 float32) error {
-	ret := _m.Called(_a0, _a1, _a2, _a3)
+// GetUserByID provides a mock function with given fields: _a0, _a1
+func (_m *UserStorage) GetUserByID(_a0 context.Context, _a1 string) (sharedtypes.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPendingAccruals provides a mock function with given fields: _a0, _a1
+func (_m *UserStorage) ListPendingAccruals(_a0 context.Context, _a1 string) ([]sharedtypes.PendingAccrual, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.PendingAccrual
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.PendingAccrual); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.PendingAccrual)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTOTPSecret provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorage) SetTOTPSecret(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePasswordHash provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorage) UpdatePasswordHash(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -112,13 +219,13 @@ func (_m *UserStorage) UpdateUser(_a0 context.Context, _a1 string, _a2 string, _
 	return r0
 }
 
-// WithdrawBalance provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4, _a5, _a6
-func (_m *UserStorage) WithdrawBalance(_a0 context.Context, _a1 string, _a2 string, _a3 float32, _a4 float32, _a5 float32, _a6 sharedtypes.WithdrawalStorager) error {
-	ret := _m.Called(_a0, _a1, _a2, _a3, _a4, _a5, _a6)
+// UpdateUser provides a mock function with given fields: ctx, uid, orderID, accrual, bonus
+func (_m *UserStorage) UpdateUser(ctx context.Context, uid string, orderID string, accrual float32, bonus float32) error {
+	ret := _m.Called(ctx, uid, orderID, accrual, bonus)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32, float32, float32, sharedtypes.WithdrawalStorager) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3, _a4, _a5, _a6)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32, float32) error); ok {
+		r0 = rf(ctx, uid, orderID, accrual, bonus)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -126,6 +233,69 @@ func (_m *UserStorage) WithdrawBalance(_a0 context.Context, _a1 string, _a2 stri
 	return r0
 }
 
+// UseRecoveryCode provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorage) UseRecoveryCode(_a0 context.Context, _a1 string, _a2 string) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTOTPStep provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorage) UseTOTPStep(_a0 context.Context, _a1 string, _a2 int64) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithdrawBalance provides a mock function with given fields: ctx, uid, orderID, amount
+func (_m *UserStorage) WithdrawBalance(ctx context.Context, uid string, orderID string, amount float32) (sharedtypes.Balance, error) {
+	ret := _m.Called(ctx, uid, orderID, amount)
+
+	var r0 sharedtypes.Balance
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) sharedtypes.Balance); ok {
+		r0 = rf(ctx, uid, orderID, amount)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Balance)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, float32) error); ok {
+		r1 = rf(ctx, uid, orderID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserStorage interface {
 	mock.TestingT
 	Cleanup(func())
@@ -35,6 +35,76 @@ func (_m *UserStorager) CreateUser(_a0 context.Context, _a1 sharedtypes.Credenti
 	return r0, r1
 }
 
+// DeleteUser provides a mock function with given fields: _a0, _a1
+func (_m *UserStorager) DeleteUser(_a0 context.Context, _a1 string) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTOTP provides a mock function with given fields: _a0, _a1
+func (_m *UserStorager) DisableTOTP(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTOTP provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *UserStorager) EnableTOTP(_a0 context.Context, _a1 string, _a2 int64, _a3 []string) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportUserData provides a mock function with given fields: _a0, _a1
+func (_m *UserStorager) ExportUserData(_a0 context.Context, _a1 string) (sharedtypes.UserData, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.UserData
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.UserData); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.UserData)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBalance provides a mock function with given fields: _a0, _a1
 func (_m *UserStorager) GetBalance(_a0 context.Context, _a1 string) (sharedtypes.Balance, error) {
 	ret := _m.Called(_a0, _a1)
@@ -77,13 +147,57 @@ func (_m *UserStorager) GetUser(_a0 context.Context, _a1 sharedtypes.Credentials
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *UserStorager) UpdateUser(_a0 context.Context, _a1 string, _a2 string, _a3 float32) error {
-	ret := _m.Called(_a0, _a1, _a2, _a3)
+// GetUserByID provides a mock function with given fields: _a0, _a1
+func (_m *UserStorager) GetUserByID(_a0 context.Context, _a1 string) (sharedtypes.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 sharedtypes.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) sharedtypes.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPendingAccruals provides a mock function with given fields: _a0, _a1
+func (_m *UserStorager) ListPendingAccruals(_a0 context.Context, _a1 string) ([]sharedtypes.PendingAccrual, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []sharedtypes.PendingAccrual
+	if rf, ok := ret.Get(0).(func(context.Context, string) []sharedtypes.PendingAccrual); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sharedtypes.PendingAccrual)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTOTPSecret provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorager) SetTOTPSecret(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,13 +205,13 @@ func (_m *UserStorager) UpdateUser(_a0 context.Context, _a1 string, _a2 string,
 	return r0
 }
 
-// WithdrawBalance provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4, _a5, _a6
-func (_m *UserStorager) WithdrawBalance(_a0 context.Context, _a1 string, _a2 string, _a3 float32, _a4 float32, _a5 float32, _a6 sharedtypes.WithdrawalStorager) error {
-	ret := _m.Called(_a0, _a1, _a2, _a3, _a4, _a5, _a6)
+// UpdatePasswordHash provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorager) UpdatePasswordHash(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32, float32, float32, sharedtypes.WithdrawalStorager) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3, _a4, _a5, _a6)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -105,6 +219,83 @@ func (_m *UserStorager) WithdrawBalance(_a0 context.Context, _a1 string, _a2 str
 	return r0
 }
 
+// UpdateUser provides a mock function with given fields: ctx, uid, orderID, accrual, bonus
+func (_m *UserStorager) UpdateUser(ctx context.Context, uid string, orderID string, accrual float32, bonus float32) error {
+	ret := _m.Called(ctx, uid, orderID, accrual, bonus)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32, float32) error); ok {
+		r0 = rf(ctx, uid, orderID, accrual, bonus)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorager) UseRecoveryCode(_a0 context.Context, _a1 string, _a2 string) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTOTPStep provides a mock function with given fields: _a0, _a1, _a2
+func (_m *UserStorager) UseTOTPStep(_a0 context.Context, _a1 string, _a2 int64) (bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithdrawBalance provides a mock function with given fields: ctx, uid, orderID, amount
+func (_m *UserStorager) WithdrawBalance(ctx context.Context, uid string, orderID string, amount float32) (sharedtypes.Balance, error) {
+	ret := _m.Called(ctx, uid, orderID, amount)
+
+	var r0 sharedtypes.Balance
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) sharedtypes.Balance); ok {
+		r0 = rf(ctx, uid, orderID, amount)
+	} else {
+		r0 = ret.Get(0).(sharedtypes.Balance)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, float32) error); ok {
+		r1 = rf(ctx, uid, orderID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserStorager interface {
 	mock.TestingT
 	Cleanup(func())